@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+)
+
+// fileFilter narrows which files the extractor looks at. An empty
+// IncludeExts means "all files" — the default when the user never opts
+// into selectingFilters. ExcludePatterns and ExcludeDirs are additive on
+// top of IncludeExts: a file can pass the extension check and still be
+// dropped because it (or a directory it's under) matches one of these.
+type fileFilter struct {
+	Name            string
+	IncludeExts     []string
+	ExcludePatterns []string // filepath.Match patterns, e.g. "*.tmp", checked against the base name
+	ExcludeDirs     []string // directory base names to skip entirely, e.g. "node_modules", ".git"
+
+	// IncludeMatches, when non-empty, further restricts IncludeExts to files
+	// matching at least one pattern: a bare token (e.g. "vines*") is a
+	// filepath.Match glob against the base name, while a "/regex/"-wrapped
+	// token is matched against the full path. See extractor.Options.IncludeMatches.
+	IncludeMatches []string
+}
+
+// fileFilterPresets mirrors zenity's FileFilter idea: a handful of named
+// extension sets a user can pick from instead of typing extensions by hand.
+var fileFilterPresets = []fileFilter{
+	{Name: "All files"},
+	{Name: "Legal documents", IncludeExts: []string{".pdf", ".docx", ".doc"}},
+	{Name: "Spreadsheets", IncludeExts: []string{".xlsx", ".csv"}},
+}
+
+// parseCustomFilter turns a comma-separated extension list (as typed into
+// the custom entry on selectingFilters) into a fileFilter, trimming
+// whitespace and ensuring every entry starts with a dot.
+func parseCustomFilter(raw string) fileFilter {
+	var exts []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !strings.HasPrefix(part, ".") {
+			part = "." + part
+		}
+		exts = append(exts, strings.ToLower(part))
+	}
+	return fileFilter{Name: "Custom", IncludeExts: exts}
+}
+
+// parseExcludeInput splits a comma-separated list of exclude globs (as typed
+// into the exclude entry on selectingFilters) into patterns and dirs: a
+// token containing a glob metacharacter is matched against file base names
+// with filepath.Match, anything else is treated as a directory name to skip
+// outright (e.g. "node_modules", ".git").
+func parseExcludeInput(raw string) (patterns, dirs []string) {
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.ContainsAny(part, "*?[") {
+			patterns = append(patterns, part)
+		} else {
+			dirs = append(dirs, part)
+		}
+	}
+	return patterns, dirs
+}
+
+// parseMatchList splits a comma-separated list of --matches-style patterns
+// (as typed into the match entry on configuring) into a slice, trimming
+// whitespace. Unlike parseExcludeInput, no glob/regex bucketing happens here
+// since extractor.matchesIncludePatterns distinguishes the two per-pattern
+// at match time based on a "/.../" wrapper.
+func parseMatchList(raw string) []string {
+	var patterns []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		patterns = append(patterns, part)
+	}
+	return patterns
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCustomFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"single_no_dot", "pdf", []string{".pdf"}},
+		{"single_with_dot", ".pdf", []string{".pdf"}},
+		{"multiple_mixed_spacing", "pdf, .docx ,DOC", []string{".pdf", ".docx", ".doc"}},
+		{"blank_entries_dropped", "pdf,,  ,docx", []string{".pdf", ".docx"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCustomFilter(tt.raw)
+			if !reflect.DeepEqual(got.IncludeExts, tt.want) {
+				t.Errorf("parseCustomFilter(%q).IncludeExts = %v, want %v", tt.raw, got.IncludeExts, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExcludeInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantPatterns []string
+		wantDirs     []string
+	}{
+		{"empty", "", nil, nil},
+		{"dir_only", "node_modules", nil, []string{"node_modules"}},
+		{"glob_only", "*.tmp", []string{"*.tmp"}, nil},
+		{"mixed", "node_modules, .git, *.tmp, cache?", []string{"*.tmp", "cache?"}, []string{"node_modules", ".git"}},
+		{"bracket_glob", "[abc]*", []string{"[abc]*"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, dirs := parseExcludeInput(tt.raw)
+			if !reflect.DeepEqual(patterns, tt.wantPatterns) {
+				t.Errorf("parseExcludeInput(%q) patterns = %v, want %v", tt.raw, patterns, tt.wantPatterns)
+			}
+			if !reflect.DeepEqual(dirs, tt.wantDirs) {
+				t.Errorf("parseExcludeInput(%q) dirs = %v, want %v", tt.raw, dirs, tt.wantDirs)
+			}
+		})
+	}
+}
+
+func TestParseMatchList(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"single", "*.pdf", []string{"*.pdf"}},
+		{"multiple_with_regex_token", "*.pdf, vines*, /^foo.*$/", []string{"*.pdf", "vines*", "/^foo.*$/"}},
+		{"blank_entries_dropped", "*.pdf,, ", []string{"*.pdf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMatchList(tt.raw)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMatchList(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// filtersFilePath returns os.UserConfigDir()/camila/filters.json, the same
+// "camila" config directory internal/config's config.toml lives in (see
+// loadPrefs/persistPrefs in prefspersist.go) — filters get their own file
+// since, unlike export format/debug mode/recent dirs, they aren't part of
+// the shared Prefs shape.
+func filtersFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "camila", "filters.json"), nil
+}
+
+// loadLastFilter reads the last-used fileFilter, returning the zero value
+// (which readers treat as "All files") if none was ever saved, matching the
+// tolerant-default style of loadPersistedState.
+func loadLastFilter() (fileFilter, bool) {
+	path, err := filtersFilePath()
+	if err != nil {
+		return fileFilter{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fileFilter{}, false
+	}
+
+	var f fileFilter
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fileFilter{}, false
+	}
+	return f, true
+}
+
+// saveLastFilter persists f to filters.json atomically (temp file + rename),
+// swallowing errors since a failed save shouldn't interrupt the wizard.
+func saveLastFilter(f fileFilter) {
+	path, err := filtersFilePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "filters-*.json.tmp")
+	if err != nil {
+		return
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	_ = os.Rename(tmpPath, path)
+}
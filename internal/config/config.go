@@ -0,0 +1,87 @@
+// Package config loads and saves user preferences shared across the
+// camila-app tools: language, last-used export settings, debug mode, a
+// default litigant name, and a bounded MRU of recently selected
+// directories. Not every tool uses every field — file-indexer-tui keeps its
+// own bookmark/recent-directory history in its own state.json (see that
+// binary's bookmarks.go) rather than RecentDirs here, since for it that's
+// bookmark data rather than a preference; the root camila-app CLI has no
+// separate state file, so its directory history lives in RecentDirs
+// instead. A tool should round-trip the fields it doesn't manage unchanged
+// so saving its own preferences doesn't clobber another tool's.
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Prefs is the on-disk shape of config.toml.
+type Prefs struct {
+	IsSpanish    bool     `toml:"is_spanish"`
+	ExportFormat string   `toml:"export_format"`
+	DebugMode    bool     `toml:"debug_mode"`
+	LitigantName string   `toml:"litigant_name"`
+	RecentDirs   []string `toml:"recent_dirs"`
+}
+
+// defaultPrefs matches the zero-value defaults initialModel used before
+// preferences were persisted, so a first run behaves exactly as before.
+var defaultPrefs = Prefs{IsSpanish: true, ExportFormat: "excel"}
+
+// Path returns os.UserConfigDir()/camila/config.toml — XDG config on
+// Linux/macOS, %AppData% on Windows, matching os.UserConfigDir's own
+// per-OS behavior.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "camila", "config.toml"), nil
+}
+
+// Load reads config.toml, returning defaultPrefs if the file doesn't exist
+// yet or can't be parsed, matching the tolerant-default style the rest of
+// this repo uses for persisted state.
+func Load() Prefs {
+	path, err := Path()
+	if err != nil {
+		return defaultPrefs
+	}
+
+	var prefs Prefs
+	if _, err := toml.DecodeFile(path, &prefs); err != nil {
+		return defaultPrefs
+	}
+	return prefs
+}
+
+// Save writes config.toml atomically (temp file + rename) so a crash
+// mid-write can't leave a truncated, unreadable file behind.
+func (p Prefs) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "config-*.toml.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if err := toml.NewEncoder(tmp).Encode(p); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,56 @@
+package extractor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"camila-app/internal/vfs"
+)
+
+// isArchive reports whether path's extension matches one of the archive
+// formats internal/vfs.Open knows how to dispatch.
+func isArchive(path string) bool {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".zip"),
+		strings.HasSuffix(lower, ".tar"),
+		strings.HasSuffix(lower, ".tar.gz"),
+		strings.HasSuffix(lower, ".tgz"),
+		strings.HasSuffix(lower, ".tar.bz2"):
+		return true
+	}
+	return false
+}
+
+// archiveEntries opens path as an archive via internal/vfs and returns one
+// FileRecord per entry inside, with Path formatted as
+// "outer.zip!inner/file.txt" the way a zip-within-a-zip viewer would show
+// it, and ArchivePath set to the container's own path. It errors only if
+// the archive itself can't be opened; individual unreadable entries are
+// skipped rather than aborting the rest.
+func archiveEntries(path string) ([]FileRecord, error) {
+	v, err := vfs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer v.Close()
+
+	var records []FileRecord
+	err = v.Walk(func(entry vfs.Entry) error {
+		records = append(records, FileRecord{
+			Path:        fmt.Sprintf("%s!%s", path, entry.Name),
+			Name:        filepath.Base(entry.Name),
+			Size:        entry.Size,
+			ModTime:     entry.ModTime,
+			Hash:        entry.Hash,
+			InArchive:   true,
+			ArchivePath: path,
+		})
+		return nil
+	})
+	if err != nil {
+		return records, err
+	}
+	return records, nil
+}
@@ -0,0 +1,425 @@
+// Package extractor is a pure-Go replacement for the Python
+// file_metadata_extractor.py script: it walks a directory, extracts file
+// metadata in parallel, and hands the result to writer.go for xlsx/csv
+// output.
+package extractor
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// FileRecord is one row of extracted metadata.
+type FileRecord struct {
+	Path        string
+	Name        string
+	Size        int64
+	ModTime     time.Time
+	Mime        string // sniffed from the first 512 bytes; "" if unreadable
+	Hash        string
+	PageCount   int    // PDF page count; 0 if not a PDF or unreadable
+	Extra       string // DOCX core-property summary, empty otherwise
+	InArchive   bool   // true for a row produced by ScanArchives recursing into a zip/tar
+	ArchivePath string // the container archive's own path; empty unless InArchive
+}
+
+// Options configures a Walk.
+type Options struct {
+	Debug bool
+
+	// IncludeExt, when non-empty, restricts Walk to files whose lowercased
+	// extension (with leading dot, e.g. ".pdf") appears in the list. An
+	// empty list matches every file.
+	IncludeExt []string
+
+	// ExcludePatterns are filepath.Match patterns (e.g. "*.tmp") checked
+	// against each file's base name; a match drops the file.
+	ExcludePatterns []string
+
+	// ExcludeDirs are directory base names (e.g. "node_modules", ".git")
+	// that are skipped entirely, along with everything under them.
+	ExcludeDirs []string
+
+	// IncludeMatches, when non-empty, restricts Walk to files matching at
+	// least one pattern: a bare token (e.g. "vines*") is a filepath.Match
+	// glob against the base name, while a "/regex/"-wrapped token compiles
+	// as a regexp and is matched against the full path.
+	IncludeMatches []string
+
+	// ScanArchives, when true, recurses into .zip/.tar/.tar.gz/.tgz/.tar.bz2
+	// files and emits one row per entry inside instead of one row for the
+	// archive itself.
+	ScanArchives bool
+
+	// Workers caps the number of concurrent file-processing goroutines.
+	// Zero (the default) means runtime.NumCPU().
+	Workers int
+
+	// Shards, when greater than 1, splits root's file list into that many
+	// partitions (by path index, round-robin) so a tree too large for one
+	// run can be indexed across several invocations. Shard selects which
+	// partition this Walk processes; it's ignored when Shards <= 1.
+	Shards int
+	Shard  int
+}
+
+// ProgressFunc is called once per finished file. current/total only make
+// sense as a running count since workers finish out of order; callers that
+// need deterministic output should use Walk's returned []FileRecord, not the
+// order progress callbacks arrive in. bytesDone is the cumulative size of
+// every file finished so far.
+type ProgressFunc func(current, total int, bytesDone int64, path string)
+
+// Stats summarizes how a Walk's filters (IncludeExt, ExcludePatterns,
+// ExcludeDirs, IncludeMatches) disposed of the files under root, for
+// callers that want to report e.g. "120 matched, 40 skipped".
+type Stats struct {
+	Matched int
+	Skipped int
+}
+
+// Walk scans root with a worker pool sized to opts.Workers (or
+// runtime.NumCPU() if unset) — bounded job channel of paths, N workers
+// consuming, one collector goroutine gathering results — then sorts the
+// output by path so it's deterministic regardless of which worker finished
+// which file first. When opts.Shards is set, only the opts.Shard-th
+// partition of root's file list is processed, letting a tree too large for
+// one run be split across several invocations. Cancelling ctx stops the
+// walk and the worker pool as soon as the workers notice, and Walk returns
+// ctx.Err().
+func Walk(ctx context.Context, root string, opts Options, onProgress ProgressFunc) ([]FileRecord, Stats, error) {
+	paths, skipped, err := collectPaths(ctx, root, opts)
+	if err != nil {
+		return nil, Stats{}, err
+	}
+	paths = shardPaths(paths, opts.Shards, opts.Shard)
+	stats := Stats{Matched: len(paths), Skipped: skipped}
+	total := len(paths)
+	if total == 0 {
+		return nil, stats, nil
+	}
+
+	jobs := make(chan string, total)
+	for _, p := range paths {
+		jobs <- p
+	}
+	close(jobs)
+
+	// Unbuffered rather than sized to total: a single archive path can
+	// expand into many rows via opts.ScanArchives, so total no longer
+	// bounds the number of records produced.
+	results := make(chan FileRecord)
+	numWorkers := opts.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	if numWorkers > total {
+		numWorkers = total
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	completed := 0
+	var bytesDone int64
+
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				select {
+				case <-ctx.Done():
+					continue // Drain jobs without doing the work, so close(jobs) doesn't block.
+				default:
+				}
+				recs := extractFile(p, opts)
+				var size int64
+				for _, rec := range recs {
+					results <- rec
+					size += rec.Size
+				}
+				if onProgress != nil {
+					mu.Lock()
+					completed++
+					bytesDone += size
+					n, b := completed, bytesDone
+					mu.Unlock()
+					onProgress(n, total, b, p)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	records := make([]FileRecord, 0, total)
+	for rec := range results {
+		records = append(records, rec)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, Stats{}, err
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Path < records[j].Path })
+
+	return records, stats, nil
+}
+
+// collectPaths gathers every regular file under root up front so the worker
+// pool can be sized against a known total instead of scanning on the fly. It
+// returns the matching paths and a count of files skipped by a filter (not
+// counting directories pruned via opts.ExcludeDirs). opts.IncludeExt, when
+// non-empty, restricts the result to matching extensions; opts.ExcludeDirs
+// prunes whole subtrees, opts.ExcludePatterns drops individual files by
+// base-name glob, and opts.IncludeMatches further restricts to files matching
+// at least one of its patterns. It checks ctx between entries so a cancelled
+// scan doesn't finish walking a huge tree just to throw the result away.
+func collectPaths(ctx context.Context, root string, opts Options) ([]string, int, error) {
+	var paths []string
+	skipped := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return nil // Skip unreadable entries rather than aborting the whole walk.
+		}
+		if d.IsDir() {
+			if p != root && isExcludedDir(d.Name(), opts.ExcludeDirs) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !matchesExt(p, opts.IncludeExt) {
+			skipped++
+			return nil
+		}
+		if isExcludedFile(d.Name(), opts.ExcludePatterns) {
+			skipped++
+			return nil
+		}
+		if len(opts.IncludeMatches) > 0 && !matchesIncludePatterns(p, opts.IncludeMatches) {
+			skipped++
+			return nil
+		}
+		paths = append(paths, p)
+		return nil
+	})
+	return paths, skipped, err
+}
+
+// matchesIncludePatterns reports whether path satisfies at least one of
+// patterns. A "/regex/"-wrapped token is compiled as a regexp and matched
+// against the full path; any other token is a filepath.Match glob checked
+// against the base name. A malformed pattern is treated as a non-match
+// rather than aborting the walk.
+func matchesIncludePatterns(path string, patterns []string) bool {
+	name := filepath.Base(path)
+	for _, pattern := range patterns {
+		if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err == nil && re.MatchString(path) {
+				return true
+			}
+			continue
+		}
+		if ok, err := filepath.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedDir reports whether name matches one of excludeDirs exactly.
+func isExcludedDir(name string, excludeDirs []string) bool {
+	for _, dir := range excludeDirs {
+		if name == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedFile reports whether name matches one of excludePatterns via
+// filepath.Match. A malformed pattern is treated as a non-match rather than
+// aborting the walk.
+func isExcludedFile(name string, excludePatterns []string) bool {
+	for _, pattern := range excludePatterns {
+		if ok, err := filepath.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shardPaths returns every path whose index falls in the given shard of a
+// shards-way round-robin split, or paths unchanged if shards <= 1. Splitting
+// by index rather than by hash keeps the partition stable run-to-run as long
+// as the underlying directory listing doesn't change.
+func shardPaths(paths []string, shards, shard int) []string {
+	if shards <= 1 {
+		return paths
+	}
+	var out []string
+	for i, p := range paths {
+		if i%shards == shard {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// matchesExt reports whether path's lowercased extension is in includeExt,
+// or true unconditionally when includeExt is empty.
+func matchesExt(path string, includeExt []string) bool {
+	if len(includeExt) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, want := range includeExt {
+		if ext == want {
+			return true
+		}
+	}
+	return false
+}
+
+// extractFile returns metadata for path, or — when opts.ScanArchives is set
+// and path looks like a supported archive — one record per entry inside it
+// instead. A broken archive falls back to a single record describing the
+// archive file itself, rather than dropping it from the index.
+func extractFile(path string, opts Options) []FileRecord {
+	if opts.ScanArchives && isArchive(path) {
+		if recs, err := archiveEntries(path); err == nil {
+			return recs
+		}
+	}
+
+	rec := FileRecord{Path: path, Name: filepath.Base(path)}
+
+	if info, err := os.Stat(path); err == nil {
+		rec.Size = info.Size()
+		rec.ModTime = info.ModTime()
+	}
+	rec.Mime = sniffMime(path)
+	if hash, err := hashFile(path); err == nil {
+		rec.Hash = hash
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		rec.PageCount = pdfPageCount(path)
+	case ".docx":
+		rec.Extra = docxCoreProperties(path)
+	}
+
+	return []FileRecord{rec}
+}
+
+// Rescan re-extracts metadata for an explicit list of paths instead of
+// walking a directory tree, for callers (like watch mode) that already know
+// exactly which files changed and want to patch an existing result set
+// rather than pay for a full Walk.
+func Rescan(paths []string, opts Options) []FileRecord {
+	var records []FileRecord
+	for _, p := range paths {
+		records = append(records, extractFile(p, opts)...)
+	}
+	return records
+}
+
+// sniffMime reads up to the first 512 bytes of path and classifies them with
+// net/http.DetectContentType, returning "" rather than an error on failure
+// since a missing MIME type shouldn't abort the whole scan.
+func sniffMime(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, err := f.Read(buf)
+	if err != nil && err != io.EOF {
+		return ""
+	}
+	return http.DetectContentType(buf[:n])
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// pdfPageCount returns 0 rather than an error on failure, matching the
+// tolerant-default style readDirectoryItems and getDownloadsDirectory use
+// elsewhere: a metadata sweep shouldn't abort over one unreadable PDF.
+func pdfPageCount(path string) int {
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	return r.NumPage()
+}
+
+// docxCoreProperties pulls title/creator out of docProps/core.xml, the part
+// of the OOXML zip that every Word-generated .docx carries.
+func docxCoreProperties(path string) string {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return ""
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name != "docProps/core.xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return ""
+		}
+		defer rc.Close()
+
+		var props struct {
+			Title   string `xml:"title"`
+			Creator string `xml:"creator"`
+		}
+		if err := xml.NewDecoder(rc).Decode(&props); err != nil {
+			return ""
+		}
+		return fmt.Sprintf("title=%q creator=%q", props.Title, props.Creator)
+	}
+
+	return ""
+}
@@ -0,0 +1,61 @@
+package extractor
+
+import "testing"
+
+func TestShardPaths(t *testing.T) {
+	paths := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name   string
+		shards int
+		shard  int
+		want   []string
+	}{
+		{"disabled", 0, 0, paths},
+		{"disabled_negative", -1, 0, paths},
+		{"single_shard", 1, 0, paths},
+		{"two_shards_first", 2, 0, []string{"a", "c", "e"}},
+		{"two_shards_second", 2, 1, []string{"b", "d"}},
+		{"more_shards_than_paths", 10, 3, []string{"d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shardPaths(paths, tt.shards, tt.shard)
+			if len(got) != len(tt.want) {
+				t.Fatalf("shardPaths(%v, %d, %d) = %v, want %v", paths, tt.shards, tt.shard, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("shardPaths(%v, %d, %d) = %v, want %v", paths, tt.shards, tt.shard, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchesIncludePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		patterns []string
+		want     bool
+	}{
+		{"no_patterns", "/root/report.pdf", nil, false},
+		{"glob_match_base_name", "/root/report.pdf", []string{"*.pdf"}, true},
+		{"glob_no_match", "/root/report.pdf", []string{"*.docx"}, false},
+		{"glob_against_full_path_fails", "/root/sub/report.pdf", []string{"sub/*.pdf"}, false},
+		{"regex_match_full_path", "/root/sub/report.pdf", []string{"/sub\\/.*\\.pdf/"}, true},
+		{"regex_no_match", "/root/sub/report.pdf", []string{"/other\\/.*\\.pdf/"}, false},
+		{"malformed_regex_is_non_match", "/root/report.pdf", []string{"/[/"}, false},
+		{"second_pattern_matches", "/root/report.pdf", []string{"*.docx", "*.pdf"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesIncludePatterns(tt.path, tt.patterns); got != tt.want {
+				t.Errorf("matchesIncludePatterns(%q, %v) = %v, want %v", tt.path, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package extractor
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// columns is the shared header row for both the xlsx and csv writers.
+var columns = []string{"Path", "Name", "Size", "ModTime", "Mime", "Hash", "PageCount", "Extra", "InArchive", "ArchivePath"}
+
+// row renders a FileRecord the same way for both output formats.
+func (r FileRecord) row() []string {
+	return []string{
+		r.Path,
+		r.Name,
+		strconv.FormatInt(r.Size, 10),
+		r.ModTime.Format(time.RFC3339),
+		r.Mime,
+		r.Hash,
+		strconv.Itoa(r.PageCount),
+		r.Extra,
+		strconv.FormatBool(r.InArchive),
+		r.ArchivePath,
+	}
+}
+
+// WriteXLSX writes records to path as a single-sheet workbook.
+func WriteXLSX(records []FileRecord, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	const sheet = "Files"
+	f.SetSheetName(f.GetSheetName(0), sheet)
+
+	for col, header := range columns {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(sheet, cell, header)
+	}
+	for i, rec := range records {
+		row := rec.row()
+		for col, value := range row {
+			cell, _ := excelize.CoordinatesToCellName(col+1, i+2)
+			f.SetCellValue(sheet, cell, value)
+		}
+	}
+
+	return f.SaveAs(path)
+}
+
+// WriteCSV writes records to path as CSV with the same column order as
+// WriteXLSX.
+func WriteCSV(records []FileRecord, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := w.Write(rec.row()); err != nil {
+			return err
+		}
+	}
+
+	return w.Error()
+}
@@ -0,0 +1,43 @@
+// Package picker wraps each OS's native directory-chooser dialog (zenity/
+// kdialog on Linux, osascript on macOS, PowerShell's FolderBrowserDialog on
+// Windows) behind a single SelectDirectory call, for callers that would
+// rather shell out to the desktop's own file chooser than drive a TUI list.
+package picker
+
+import "errors"
+
+// ErrCancelled is returned by SelectDirectory when the user dismissed the
+// dialog without choosing a directory, as distinct from the dialog failing
+// to launch at all (e.g. no supported backend installed).
+var ErrCancelled = errors.New("picker: selection cancelled")
+
+// config holds the options a caller can set via Option functions.
+type config struct {
+	title    string
+	filename string // default/starting path shown in the dialog
+}
+
+// Option configures a SelectDirectory call.
+type Option func(*config)
+
+// Title sets the dialog window's title.
+func Title(title string) Option {
+	return func(c *config) { c.title = title }
+}
+
+// Filename sets the directory the dialog starts in.
+func Filename(filename string) Option {
+	return func(c *config) { c.filename = filename }
+}
+
+// SelectDirectory opens the platform's native directory chooser and blocks
+// until the user picks a directory or cancels. It returns ErrCancelled on
+// cancel, or an error identifying why no backend could be launched (e.g.
+// "no directory picker backend found" on Linux without zenity or kdialog).
+func SelectDirectory(opts ...Option) (string, error) {
+	cfg := config{title: "Select a directory"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return selectDirectory(cfg)
+}
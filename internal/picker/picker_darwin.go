@@ -0,0 +1,50 @@
+package picker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// selectDirectory shells out to osascript, driving the standard Cocoa
+// "choose folder" dialog. AppleScript raises error -128 ("User canceled")
+// when the user dismisses the dialog; selectDirectory looks for that code
+// in stderr to tell a real cancel apart from any other script failure
+// (a missing osascript binary, a malformed script, Automation permission
+// denied, etc.), which must not be reported as ErrCancelled.
+func selectDirectory(cfg config) (string, error) {
+	script := fmt.Sprintf(`POSIX path of (choose folder with prompt %q%s)`, cfg.title, defaultLocationClause(cfg.filename))
+	cmd := exec.Command("osascript", "-e", script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", err
+		}
+		if strings.Contains(stderr.String(), "(-128)") {
+			return "", ErrCancelled
+		}
+		return "", fmt.Errorf("picker: osascript failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	path := strings.TrimSpace(string(out))
+	if path == "" {
+		return "", ErrCancelled
+	}
+	return path, nil
+}
+
+// defaultLocationClause builds the "default location" clause choose folder
+// accepts, or "" when filename is unset so the dialog opens wherever macOS
+// last left it. default location's parameter type is alias, and a bare
+// quoted string won't coerce to one (AppleScript's implicit string->alias
+// coercion expects HFS colon-paths, not POSIX slash-paths) — wrapping it in
+// "POSIX file" first turns it into a file specifier AppleScript can coerce.
+func defaultLocationClause(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	return fmt.Sprintf(" default location (POSIX file %q)", filename)
+}
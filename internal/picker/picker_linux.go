@@ -0,0 +1,66 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// selectDirectory prefers zenity (GTK) and falls back to kdialog (KDE),
+// since those are the two directory-chooser backends present across
+// mainstream Linux desktop environments. Both exit 1 with no output when
+// the user cancels, which selectDirectory reports as ErrCancelled; any
+// other failure (a crash, no $DISPLAY/Wayland session, a missing D-Bus,
+// etc.) is reported as a real error instead.
+func selectDirectory(cfg config) (string, error) {
+	if path, err := runZenity(cfg); err == nil || !errors.Is(err, exec.ErrNotFound) {
+		return path, err
+	}
+	if path, err := runKdialog(cfg); err == nil || !errors.Is(err, exec.ErrNotFound) {
+		return path, err
+	}
+	return "", errors.New("picker: no directory picker backend found (install zenity or kdialog)")
+}
+
+func runZenity(cfg config) (string, error) {
+	args := []string{"--file-selection", "--directory", "--title=" + cfg.title}
+	if cfg.filename != "" {
+		args = append(args, "--filename="+cfg.filename+"/")
+	}
+	return runPickerCmd(exec.Command("zenity", args...))
+}
+
+func runKdialog(cfg config) (string, error) {
+	args := []string{"--getexistingdirectory", cfg.filename, "--title", cfg.title}
+	return runPickerCmd(exec.Command("kdialog", args...))
+}
+
+// runPickerCmd runs a picker subprocess and trims its stdout. Both zenity
+// and kdialog exit with status 1 specifically for "user cancelled" (a plain
+// dismiss or the window's close button); any other non-zero exit is a real
+// failure and is reported as such rather than folded into ErrCancelled, with
+// the subprocess's stderr (which Output populates onto the *exec.ExitError
+// when cmd.Stderr is nil) included for diagnosis.
+func runPickerCmd(cmd *exec.Cmd) (string, error) {
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", err
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", ErrCancelled
+		}
+		stderr := ""
+		if exitErr != nil {
+			stderr = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("picker: %s failed: %s", cmd.Args[0], stderr)
+	}
+	path := strings.TrimSpace(string(out))
+	if path == "" {
+		return "", ErrCancelled
+	}
+	return path, nil
+}
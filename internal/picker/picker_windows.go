@@ -0,0 +1,54 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// selectDirectory shells out to PowerShell to drive
+// System.Windows.Forms.FolderBrowserDialog, since Go has no native binding
+// for the Windows common dialogs. The script prints the chosen path on its
+// own line, or nothing (with a clean exit) if the user cancels -- a
+// dismissed dialog is not a script failure, so it never reaches the
+// err != nil branch below at all. Anything that does land there (a missing
+// powershell, an execution-policy error, a typo in the script, etc.) is a
+// real failure and must not be reported as ErrCancelled.
+func selectDirectory(cfg config) (string, error) {
+	script := fmt.Sprintf(`
+Add-Type -AssemblyName System.Windows.Forms
+$dialog = New-Object System.Windows.Forms.FolderBrowserDialog
+$dialog.Description = %s
+$dialog.SelectedPath = %s
+if ($dialog.ShowDialog() -eq [System.Windows.Forms.DialogResult]::OK) {
+	Write-Output $dialog.SelectedPath
+}
+`, psQuote(cfg.title), psQuote(cfg.filename))
+
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", err
+		}
+		stderr := ""
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("picker: powershell failed: %s", stderr)
+	}
+	path := strings.TrimSpace(string(out))
+	if path == "" {
+		return "", ErrCancelled
+	}
+	return path, nil
+}
+
+// psQuote wraps s in single quotes for embedding in a PowerShell script,
+// doubling any single quotes inside it the way PowerShell's own quoting
+// rules require.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
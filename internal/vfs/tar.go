@@ -0,0 +1,87 @@
+package vfs
+
+import (
+	"archive/tar"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// tarVFS reads a tar stream, already decompressed (if needed) by whichever
+// openTar* constructor built it. Tar headers carry no checksum of their
+// own, so each entry is hashed as it streams through on Walk.
+type tarVFS struct {
+	f  *os.File
+	r  io.Reader // the tar stream itself: f, or a gzip/bzip2 reader wrapping f
+	gz *gzip.Reader
+}
+
+func openTar(filename string) (VFS, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &tarVFS{f: f, r: f}, nil
+}
+
+func openTarGzip(filename string) (VFS, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &tarVFS{f: f, r: gz, gz: gz}, nil
+}
+
+func openTarBzip2(filename string) (VFS, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &tarVFS{f: f, r: bzip2.NewReader(f)}, nil
+}
+
+func (v *tarVFS) Walk(fn func(Entry) error) error {
+	tr := tar.NewReader(v.r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			continue // Skip this entry; the rest of the archive is still good.
+		}
+
+		entry := Entry{
+			Name:    hdr.Name,
+			Size:    hdr.Size,
+			ModTime: hdr.ModTime,
+			Hash:    hex.EncodeToString(h.Sum(nil)),
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+func (v *tarVFS) Close() error {
+	if v.gz != nil {
+		v.gz.Close()
+	}
+	return v.f.Close()
+}
@@ -0,0 +1,47 @@
+// Package vfs opens archive files (.zip, .tar, .tar.gz, .tgz, .tar.bz2) as a
+// read-only virtual filesystem, so internal/extractor can walk the contents
+// of a backup archive the same way it walks a directory on disk.
+package vfs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Entry is one regular file inside an archive, as surfaced by VFS.Walk.
+// Directory entries are never reported.
+type Entry struct {
+	Name    string // path of the entry within the archive, e.g. "inner/file.txt"
+	Size    int64
+	ModTime time.Time
+	Hash    string // "crc32:%08x" for zip (reused from the header), sha256 hex for tar
+}
+
+// VFS is a read-only view over an archive's contents, returned by Open.
+type VFS interface {
+	// Walk calls fn once per regular file entry in the archive, in archive
+	// order. It stops and returns fn's error if fn returns one.
+	Walk(fn func(Entry) error) error
+	io.Closer
+}
+
+// Open dispatches on filename's extension (case-insensitively) and returns
+// a VFS for it, mirroring the .tar.gz/.tgz double-extension edge case the
+// same way archive/tar callers conventionally do: by checking the longer
+// suffix first. Callers must Close the returned VFS.
+func Open(filename string) (VFS, error) {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return openZip(filename)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return openTarGzip(filename)
+	case strings.HasSuffix(lower, ".tar.bz2"):
+		return openTarBzip2(filename)
+	case strings.HasSuffix(lower, ".tar"):
+		return openTar(filename)
+	}
+	return nil, fmt.Errorf("vfs: unsupported archive format: %s", filename)
+}
@@ -0,0 +1,120 @@
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTarArchive writes a single-entry tar stream to path, gzip-compressing
+// it first when gz is true.
+func writeTarArchive(t *testing.T, path string, gz bool) {
+	t.Helper()
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	content := []byte("hello from inside an archive")
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    "inner/file.txt",
+		Size:    int64(len(content)),
+		Mode:    0o644,
+		ModTime: time.Now(),
+	}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+
+	data := tarBuf.Bytes()
+	if gz {
+		var gzBuf bytes.Buffer
+		gw := gzip.NewWriter(&gzBuf)
+		if _, err := gw.Write(data); err != nil {
+			t.Fatalf("gzip Write: %v", err)
+		}
+		if err := gw.Close(); err != nil {
+			t.Fatalf("gzip Close: %v", err)
+		}
+		data = gzBuf.Bytes()
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func writeZipArchive(t *testing.T, path string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("inner/file.txt")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := w.Write([]byte("hello from inside a zip")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip Close: %v", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+// TestOpenDispatch confirms Open picks the right backend per extension,
+// including the .tar.gz/.tgz double-extension case, and that each backend
+// can actually walk the entry it was given.
+func TestOpenDispatch(t *testing.T) {
+	dir := t.TempDir()
+
+	tarPath := filepath.Join(dir, "archive.tar")
+	writeTarArchive(t, tarPath, false)
+
+	tarGzPath := filepath.Join(dir, "archive.tar.gz")
+	writeTarArchive(t, tarGzPath, true)
+
+	tgzPath := filepath.Join(dir, "archive.tgz")
+	writeTarArchive(t, tgzPath, true)
+
+	zipPath := filepath.Join(dir, "archive.zip")
+	writeZipArchive(t, zipPath)
+
+	for _, path := range []string{tarPath, tarGzPath, tgzPath, zipPath} {
+		v, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open(%q) error: %v", path, err)
+		}
+		var names []string
+		if err := v.Walk(func(e Entry) error {
+			names = append(names, e.Name)
+			return nil
+		}); err != nil {
+			t.Fatalf("Walk(%q) error: %v", path, err)
+		}
+		if err := v.Close(); err != nil {
+			t.Fatalf("Close(%q) error: %v", path, err)
+		}
+		if len(names) != 1 || names[0] != "inner/file.txt" {
+			t.Errorf("Open(%q) Walk entries = %v, want [inner/file.txt]", path, names)
+		}
+	}
+}
+
+func TestOpenUnsupportedExtension(t *testing.T) {
+	_, err := Open("archive.rar")
+	if err == nil {
+		t.Fatal("Open(\"archive.rar\") succeeded, want an unsupported-format error")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import "camila-app/internal/config"
+
+// maxRecentDirs bounds the MRU list so config.toml doesn't grow without
+// limit across a long-lived install, matching file-indexer-tui's
+// maxRecentDirectories.
+const maxRecentDirs = 10
+
+// loadPrefs reads config.toml via internal/config. Root only manages
+// ExportFormat, DebugMode, and RecentDirs; IsSpanish/LitigantName belong to
+// file-indexer-tui and are carried through untouched by persistPrefs.
+func loadPrefs() config.Prefs {
+	return config.Load()
+}
+
+// withRecentDir returns path prepended to recent, de-duplicated and bounded
+// to maxRecentDirs, most-recent first -- mirrors file-indexer-tui's
+// withRecent in bookmarks.go.
+func withRecentDir(recent []string, path string) []string {
+	updated := make([]string, 0, len(recent)+1)
+	updated = append(updated, path)
+	for _, p := range recent {
+		if p != path {
+			updated = append(updated, p)
+		}
+	}
+	if len(updated) > maxRecentDirs {
+		updated = updated[:maxRecentDirs]
+	}
+	return updated
+}
+
+// persistPrefs saves m's export format, debug mode, and updated recent-dirs
+// list to config.toml, swallowing errors since a failed save shouldn't
+// interrupt the wizard, matching saveLastFilter's style. Fields root doesn't
+// manage (IsSpanish, LitigantName) are round-tripped from m.prefs unchanged.
+func persistPrefs(m model) {
+	prefs := m.prefs
+	prefs.ExportFormat = m.exportFormat
+	prefs.DebugMode = m.debugMode
+	if m.selectedDir != "" {
+		prefs.RecentDirs = withRecentDir(prefs.RecentDirs, m.selectedDir)
+	}
+	_ = prefs.Save()
+}
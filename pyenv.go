@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// embeddedPyScript bundles the Python fallback backend and its dependency
+// list into the binary, so a single-file install no longer needs
+// file_metadata_extractor.py and requirements.txt placed next to it.
+//
+//go:embed file_metadata_extractor.py requirements.txt
+var embeddedPyScript embed.FS
+
+// pyScriptName and pyRequirementsName are the files embeddedPyScript ships,
+// also used as the filenames written into the cache directory.
+const (
+	pyScriptName       = "file_metadata_extractor.py"
+	pyRequirementsName = "requirements.txt"
+)
+
+// ensurePythonEnvironment extracts the embedded script and requirements into
+// os.UserCacheDir()/camila/<hash>/ (skipping the write if that hash's
+// directory is already populated) and bootstraps a virtualenv there the
+// first time, pip-installing requirements.txt. Progress and pip's own
+// output are streamed over sub as logLineMsg, the same channel
+// runPythonScript uses for the script's own stdout/stderr.
+//
+// It returns the path to the script to run and the python executable
+// (inside the venv) to run it with.
+func ensurePythonEnvironment(sub chan tea.Msg) (scriptPath string, pythonExe string, err error) {
+	script, errScript := embeddedPyScript.ReadFile(pyScriptName)
+	requirements, errReq := embeddedPyScript.ReadFile(pyRequirementsName)
+	if errScript != nil || errReq != nil {
+		return "", "", fmt.Errorf("read embedded script: %v / %v", errScript, errReq)
+	}
+
+	cacheRoot, err := os.UserCacheDir()
+	if err != nil {
+		return "", "", fmt.Errorf("locate user cache dir: %w", err)
+	}
+	cacheDir := filepath.Join(cacheRoot, "camila", scriptHash(script, requirements))
+
+	scriptPath = filepath.Join(cacheDir, pyScriptName)
+	reqPath := filepath.Join(cacheDir, pyRequirementsName)
+	venvDir := filepath.Join(cacheDir, "venv")
+	installedMarker := filepath.Join(venvDir, ".installed")
+
+	if _, err := os.Stat(scriptPath); err != nil {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return "", "", fmt.Errorf("create cache dir: %w", err)
+		}
+		if err := os.WriteFile(scriptPath, script, 0o644); err != nil {
+			return "", "", fmt.Errorf("write script: %w", err)
+		}
+		if err := os.WriteFile(reqPath, requirements, 0o644); err != nil {
+			return "", "", fmt.Errorf("write requirements: %w", err)
+		}
+	}
+
+	pythonExe = venvPython(venvDir)
+	if _, err := os.Stat(installedMarker); err == nil {
+		return scriptPath, pythonExe, nil
+	}
+
+	sub <- logLineMsg("🐍 Setting up Python environment (first run only)...")
+	if err := bootstrapVenv(venvDir, reqPath, sub); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(installedMarker, []byte("ok"), 0o644); err != nil {
+		return "", "", fmt.Errorf("write install marker: %w", err)
+	}
+
+	return scriptPath, pythonExe, nil
+}
+
+// bootstrapVenv creates venvDir with `python3 -m venv` (or `python` on
+// Windows) if it doesn't exist yet, then pip installs reqPath into it,
+// streaming both commands' output over sub the way runPythonScript streams
+// the extractor script's.
+func bootstrapVenv(venvDir, reqPath string, sub chan tea.Msg) error {
+	systemPython := "python3"
+	if runtime.GOOS == "windows" {
+		systemPython = "python"
+	}
+
+	if _, err := os.Stat(venvDir); err != nil {
+		if err := runStreamed(sub, systemPython, "-m", "venv", venvDir); err != nil {
+			return fmt.Errorf("create venv: %w", err)
+		}
+	}
+
+	pip := venvPip(venvDir)
+	if err := runStreamed(sub, pip, "install", "-r", reqPath); err != nil {
+		return fmt.Errorf("pip install: %w", err)
+	}
+
+	return nil
+}
+
+// runStreamed runs name(args...) to completion, forwarding each line of its
+// combined stdout/stderr over sub as a logLineMsg.
+func runStreamed(sub chan tea.Msg, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	streamLines(out, sub)
+	return cmd.Wait()
+}
+
+// venvPython and venvPip return the interpreter/pip paths inside a venv,
+// accounting for Windows' Scripts/ layout vs POSIX's bin/.
+func venvPython(venvDir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(venvDir, "Scripts", "python.exe")
+	}
+	return filepath.Join(venvDir, "bin", "python")
+}
+
+func venvPip(venvDir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(venvDir, "Scripts", "pip.exe")
+	}
+	return filepath.Join(venvDir, "bin", "pip")
+}
+
+// scriptHash fingerprints the embedded script + requirements so the cache
+// directory name changes whenever either does, making the "skip if hash
+// matches" check a free side effect of the path itself.
+func scriptHash(script, requirements []byte) string {
+	h := sha256.New()
+	h.Write(script)
+	h.Write(requirements)
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	bblist "github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"camila-app/internal/config"
+)
+
+// maxRecentDirectories bounds the MRU list so state.json doesn't grow
+// without limit across a long-lived install.
+const maxRecentDirectories = 20
+
+// stateSchemaVersion lets a future release change the on-disk layout of
+// persistedState and migrate old files instead of failing to load them.
+const stateSchemaVersion = 1
+
+// bookmark is a user-labeled shortcut to a directory, picked with '.
+type bookmark struct {
+	Label string `json:"label"`
+	Path  string `json:"path"`
+}
+
+// persistedState is the on-disk shape of state.json: bookmarks plus the
+// MRU of previously selected directories.
+type persistedState struct {
+	Version   int        `json:"version"`
+	Bookmarks []bookmark `json:"bookmarks"`
+	Recent    []string   `json:"recent"`
+}
+
+// stateFilePath returns os.UserConfigDir()/camila/state.json.
+func stateFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "camila", "state.json"), nil
+}
+
+// loadPersistedState reads state.json, returning a zero-value state (no
+// bookmarks, no history) if the file doesn't exist yet or can't be parsed,
+// matching the tolerant-default style of getDownloadsDirectory.
+func loadPersistedState() persistedState {
+	path, err := stateFilePath()
+	if err != nil {
+		return persistedState{Version: stateSchemaVersion}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return persistedState{Version: stateSchemaVersion}
+	}
+
+	var state persistedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return persistedState{Version: stateSchemaVersion}
+	}
+
+	return migrateState(state)
+}
+
+// migrateState upgrades older state.json schemas to the current version.
+// There's only one version so far; this is the seam future migrations hang
+// off of.
+func migrateState(state persistedState) persistedState {
+	state.Version = stateSchemaVersion
+	return state
+}
+
+// save writes state.json atomically (temp file + rename) so a crash
+// mid-write can't leave a truncated, unreadable file behind.
+func (s persistedState) save() error {
+	path, err := stateFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "state-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// withRecent returns path prepended to recent, de-duplicated and bounded to
+// maxRecentDirectories, most-recent first.
+func withRecent(recent []string, path string) []string {
+	updated := make([]string, 0, len(recent)+1)
+	updated = append(updated, path)
+	for _, p := range recent {
+		if p != path {
+			updated = append(updated, p)
+		}
+	}
+	if len(updated) > maxRecentDirectories {
+		updated = updated[:maxRecentDirectories]
+	}
+	return updated
+}
+
+// persistState saves the model's bookmarks and recent list to disk,
+// swallowing errors since a failed save shouldn't interrupt navigation.
+func (m model) persistState() {
+	state := persistedState{
+		Version:   stateSchemaVersion,
+		Bookmarks: m.bookmarks,
+		Recent:    m.recent,
+	}
+	_ = state.save()
+}
+
+// maxRecentInHomeList bounds how many entries from the MRU are prepended to
+// directoryList's home view — the full history is still reachable via the
+// recentPicker, this is just a shortcut for the handful most likely wanted.
+const maxRecentInHomeList = 3
+
+// recentSectionItems validates each of recent (most-recent first) with
+// os.Stat and returns up to maxRecentInHomeList of them as directoryItems
+// marked isRecent, so a deleted or unmounted directory doesn't show up as a
+// dead entry at the top of the list.
+func recentSectionItems(recent []string) []directoryItem {
+	var items []directoryItem
+	for _, path := range recent {
+		if len(items) >= maxRecentInHomeList {
+			break
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		items = append(items, directoryItem{
+			name:     filepath.Base(path),
+			path:     path,
+			isDir:    true,
+			isRecent: true,
+			modTime:  info.ModTime(),
+		})
+	}
+	return items
+}
+
+// persistPrefs saves the model's language/format/debug/litigant preferences
+// to config.toml, swallowing errors for the same reason persistState does.
+func (m model) persistPrefs() {
+	prefs := config.Prefs{
+		IsSpanish:    m.isSpanish,
+		ExportFormat: m.exportFormat,
+		DebugMode:    m.debugMode,
+		LitigantName: m.litigantName,
+	}
+	_ = prefs.Save()
+}
+
+// bookmarkListItems and recentListItems adapt bookmarks/recent paths to the
+// existing directoryItem list.Item so the picker lists reuse the same
+// delegate and styling as directoryList.
+func bookmarkListItems(bookmarks []bookmark) []bblist.Item {
+	items := make([]bblist.Item, len(bookmarks))
+	for i, b := range bookmarks {
+		items[i] = directoryItem{name: b.Label, path: b.Path, isDir: true}
+	}
+	return items
+}
+
+func recentListItems(recent []string) []bblist.Item {
+	items := make([]bblist.Item, len(recent))
+	for i, path := range recent {
+		items[i] = directoryItem{name: filepath.Base(path), path: path, isDir: true}
+	}
+	return items
+}
+
+// newPickerList builds a small list.Model matching directoryList's styling,
+// used for both the bookmark picker and the recent-history picker.
+func newPickerList(title string) bblist.Model {
+	delegate := bblist.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color(nordicGreen)).
+		Bold(true)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(lipgloss.Color("#626262"))
+
+	l := bblist.New(nil, delegate, 120, 15)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	return l
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fileOpKind identifies which in-app file operation fileOpInput/fileOpConfirm
+// is currently carrying out, since both states are shared by several ops.
+type fileOpKind int
+
+const (
+	fileOpNone fileOpKind = iota
+	fileOpCreateDir
+	fileOpRename
+	fileOpDelete
+)
+
+// refreshCurrentDirectory re-scans m.currentPath after a file operation,
+// refreshes the cache entry so navigating away and back doesn't show stale
+// data, and selects selectName if it's still present (empty string just
+// keeps the cursor at the top). It's synchronous, unlike navigateTo's
+// background scan, since a single create/rename/delete/move is fast enough
+// not to need a spinner.
+func (m model) refreshCurrentDirectory(selectName string) (model, tea.Cmd) {
+	items, err := readDirectoryItems(context.Background(), m.currentPath, m.hideEmptyDirs)
+	if err != nil {
+		m.error = err.Error()
+		return m, nil
+	}
+
+	m.dirCache.set(m.currentPath, dirScanResult{items: items, mtime: time.Now()})
+	dirItems := sortDirItems(prependParentEntry(m.currentPath, items), m.sortField, m.sortAscending)
+	m.allDirectories = dirItems
+	m.directoryList.SetItems(toListItems(dirItems))
+
+	idx := 0
+	if selectName != "" {
+		for i, it := range dirItems {
+			if it.name == selectName {
+				idx = i
+				break
+			}
+		}
+	}
+	m.directoryList.Select(idx)
+
+	return m, nil
+}
+
+// createDirectory, renameEntry and deleteEntry wrap the os calls the
+// fileOpInput/fileOpConfirm handlers drive, kept here so Update stays focused
+// on state transitions rather than error plumbing.
+func createDirectory(path string) error {
+	return os.Mkdir(path, 0o755)
+}
+
+func renameEntry(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+func deleteEntry(path string) error {
+	return os.RemoveAll(path)
+}
+
+func moveEntry(srcPath, destDir string) error {
+	return os.Rename(srcPath, filepath.Join(destDir, filepath.Base(srcPath)))
+}
+
+// fileOpsHint is appended to the navigation help text, only shown in
+// advancedMode since casual users shouldn't be one keystroke away from
+// deleting a directory.
+func fileOpsHint(advancedMode bool) string {
+	if !advancedMode {
+		return ""
+	}
+	return "\n  N = New dir    R = Rename    D = Delete    M = Mark move    P = Paste move"
+}
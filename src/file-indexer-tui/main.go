@@ -1,20 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"camila-app/internal/config"
 )
 
 // Styles
@@ -75,6 +79,11 @@ const (
 	configuring
 	processing
 	finished
+	namingBookmark
+	bookmarkPicker
+	recentPicker
+	fileOpInput
+	fileOpConfirm
 )
 
 // Directory item for the list
@@ -83,9 +92,14 @@ type directoryItem struct {
 	path string
 	isDir bool
 	modTime time.Time
+	size int64
+	isRecent bool // true for the "Recent" section prepended to the home directoryList
 }
 
 func (i directoryItem) Title() string {
+	if i.isRecent {
+		return fmt.Sprintf("🕑 %s", i.name)
+	}
 	if i.isDir {
 		return fmt.Sprintf("📁 %s", i.name)
 	}
@@ -93,6 +107,9 @@ func (i directoryItem) Title() string {
 }
 
 func (i directoryItem) Description() string {
+	if i.isRecent {
+		return i.path
+	}
 	if i.name == ".." {
 		return "Go up to parent directory"
 	}
@@ -130,8 +147,45 @@ type model struct {
 	filterInput     textinput.Model
 	allDirectories  []directoryItem
 	filteredDirs    []directoryItem
+	sortField       sortField // Cycled with 's'
+	sortAscending   bool      // Reversed with 'S'
 	advancedMode    bool // Toggle with Ctrl+D
 	isSpanish       bool // Language toggle with Ctrl+E
+
+	// Async directory scanning
+	dirCache        *dirCache
+	scanCancel      context.CancelFunc
+	scanGen         int
+	scanning        bool
+	scanSpinner     spinner.Model
+	hideEmptyDirs   bool
+
+	// Bookmarks and recent-directory history, persisted to state.json
+	bookmarks          []bookmark
+	recent             []string
+	bookmarkList       list.Model
+	recentList         list.Model
+	bookmarkNameInput  textinput.Model
+	pendingBookmarkDir string
+
+	// Tree view, toggled with 't'
+	treeMode bool
+	treeRoot *treeNode
+
+	// In-app file operations (N/R/D/M/P), gated behind advancedMode
+	fileOpMode      fileOpKind
+	fileOpTextInput textinput.Model
+	fileOpTarget    directoryItem
+	markedForMove   string
+
+	// Preview pane, shown to the right of the directory list on wide
+	// terminals (see previewSplitWidth)
+	previewPath    string
+	previewSummary dirSummary
+	previewLoading bool
+	previewCancel  context.CancelFunc
+	previewGen     int
+	previewCache   map[string]dirSummary
 }
 
 // Language strings
@@ -150,6 +204,12 @@ type langStrings struct {
 	selectCurrent           string
 	leftRight               string
 	filterDirectories       string
+	filterSyntaxHint        string
+	sortByName              string
+	sortByDate              string
+	sortBySize              string
+	sortByType              string
+	sortControls            string
 	advancedMode            string
 	processing              string
 	processingDetails       string
@@ -212,6 +272,12 @@ func getStrings(isSpanish bool) langStrings {
 			selectCurrent:     "Espacio = Seleccionar actual",
 			leftRight:         "←→ = Navegar directorios",
 			filterDirectories: "I = Filtrar directorios",
+			filterSyntaxHint:  "  Prefijos: /re:patrón/ para regex, glob:patrón para glob",
+			sortByName:        "Nombre",
+			sortByDate:        "Fecha",
+			sortBySize:        "Tamaño",
+			sortByType:        "Tipo",
+			sortControls:      "s = Cambiar orden    S = Invertir orden",
 			advancedMode:      "Ctrl+D = Modo avanzado",
 			processing:        "⏳ Procesando Archivos...",
 			processingDetails: "🔄 Escaneando directorio y extrayendo metadatos\n📊 Esto puede tomar tiempo para directorios grandes\n\nPresiona Ctrl+C para cancelar",
@@ -270,6 +336,12 @@ func getStrings(isSpanish bool) langStrings {
 		selectCurrent:     "Space = Select current",
 		leftRight:         "←→ = Navigate directories",
 		filterDirectories: "I = Filter directories",
+		filterSyntaxHint:  "  Prefixes: /re:pattern/ for regex, glob:pattern for glob",
+		sortByName:        "Name",
+		sortByDate:        "Date",
+		sortBySize:        "Size",
+		sortByType:        "Type",
+		sortControls:      "s = Cycle sort    S = Reverse sort",
 		advancedMode:      "Ctrl+D = Advanced mode",
 		processing:        "⏳ Processing Files...",
 		processingDetails: "🔄 Scanning directory and extracting metadata\n📊 This may take a while for large directories\n\nPress Ctrl+C to cancel",
@@ -315,10 +387,15 @@ func getStrings(isSpanish bool) langStrings {
 func initialModel() model {
 	// Get Downloads directory
 	startDir := getDownloadsDirectory()
-	
+
 	// Initialize directory list
-	items := getDirectoryItems(startDir)
-	
+	defaultSortField := sortByModTime
+	defaultSortAscending := false
+	dirItems := sortDirItems(convertToDirectoryItems(getDirectoryItems(startDir)), defaultSortField, defaultSortAscending)
+	saved := loadPersistedState()
+	prefs := config.Load()
+	items := toListItems(append(recentSectionItems(saved.Recent), dirItems...))
+
 	// Create list with nice styling and double width
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
@@ -326,9 +403,9 @@ func initialModel() model {
 		Bold(true)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
 		Foreground(lipgloss.Color("#626262"))
-		
+
 	directoryList := list.New(items, delegate, 120, 15) // Double width
-	directoryList.Title = fmt.Sprintf("Navigate: %s", startDir)
+	directoryList.Title = navTitle(startDir, defaultSortField, defaultSortAscending, getStrings(prefs.IsSpanish))
 	directoryList.SetShowStatusBar(false)
 	directoryList.SetShowHelp(false)
 
@@ -343,10 +420,27 @@ func initialModel() model {
 	filterInput.Placeholder = "Type to filter directories..."
 	filterInput.Width = 100 // Double width
 
-	// Initialize litigant input
+	// Initialize litigant input, pre-filled with the last-used name if any
 	litigantInput := textinput.New()
 	litigantInput.Placeholder = "Enter litigant name (e.g. Juan Pérez)"
 	litigantInput.Width = 100
+	litigantInput.SetValue(prefs.LitigantName)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = pathStyle
+
+	bookmarkNameInput := textinput.New()
+	bookmarkNameInput.Placeholder = "Bookmark label..."
+	bookmarkNameInput.Width = 100
+
+	fileOpTextInput := textinput.New()
+	fileOpTextInput.Width = 100
+
+	exportFormat := prefs.ExportFormat
+	if exportFormat == "" {
+		exportFormat = "excel" // Default to Excel only
+	}
 
 	return model{
 		state:         selectingDirectory,
@@ -354,13 +448,25 @@ func initialModel() model {
 		currentPath:   startDir,
 		outputInput:   ti,
 		litigantInput: litigantInput,
-		exportFormat:  "excel", // Default to Excel only
-		debugMode:     false,
+		exportFormat:  exportFormat,
+		debugMode:     prefs.DebugMode,
 		filtering:     false,
 		filterInput:   filterInput,
-		allDirectories: convertToDirectoryItems(items),
+		allDirectories: dirItems,
+		sortField:     defaultSortField,
+		sortAscending: defaultSortAscending,
 		advancedMode:  false,
-		isSpanish:     true, // Default to Spanish for Spanish users
+		isSpanish:     prefs.IsSpanish,
+		dirCache:      newDirCache(dirCacheCapacity),
+		scanSpinner:   sp,
+		hideEmptyDirs: true,
+		bookmarks:         saved.Bookmarks,
+		recent:            saved.Recent,
+		bookmarkList:      newPickerList("Bookmarks"),
+		recentList:        newPickerList("Recent Directories"),
+		bookmarkNameInput: bookmarkNameInput,
+		fileOpTextInput:   fileOpTextInput,
+		previewCache:      make(map[string]dirSummary),
 	}
 }
 
@@ -388,95 +494,16 @@ func getDownloadsDirectory() string {
 	return "."
 }
 
+// getDirectoryItems does a one-off synchronous scan used only to seed
+// initialModel before the Bubble Tea program is running; every scan
+// triggered by user navigation afterwards goes through the async
+// navigateTo/scanDirectoryCmd path in scan.go instead.
 func getDirectoryItems(dirPath string) []list.Item {
-	var items []list.Item
-	var dirItems []directoryItem
-
-	// Add parent directory option if not at root
-	if parent := filepath.Dir(dirPath); parent != dirPath {
-		items = append(items, directoryItem{
-			name:   "..",
-			path:   parent,
-			isDir:  true,
-			modTime: time.Time{}, // Parent gets zero time to always appear first
-		})
-	}
-
-	entries, err := os.ReadDir(dirPath)
-	if err != nil {
-		return items
-	}
-
-	// Collect directories with modification times
-	for _, entry := range entries {
-		if entry.IsDir() {
-			name := entry.Name()
-			// Skip hidden directories and ensure name is not empty
-			if name == "" || strings.HasPrefix(name, ".") {
-				continue
-			}
-			// Ensure the name is valid and displayable
-			if len(strings.TrimSpace(name)) == 0 {
-				continue
-			}
-			
-			// Get modification time
-			fullPath := filepath.Join(dirPath, name)
-			info, err := entry.Info()
-			var modTime time.Time
-			if err == nil {
-				modTime = info.ModTime()
-			}
-			
-			// Check if directory is empty (has files)
-			if !isDirEmpty(fullPath) {
-				dirItems = append(dirItems, directoryItem{
-					name:    name,
-					path:    fullPath,
-					isDir:   true,
-					modTime: modTime,
-				})
-			}
-		}
-	}
-
-	// Sort by modification time (most recent first)
-	sort.Slice(dirItems, func(i, j int) bool {
-		return dirItems[i].modTime.After(dirItems[j].modTime)
-	})
-
-	// Convert to list items
-	for _, dirItem := range dirItems {
-		items = append(items, dirItem)
-	}
-
-	return items
-}
-
-func isDirEmpty(dirPath string) bool {
-	entries, err := os.ReadDir(dirPath)
+	dirItems, err := readDirectoryItems(context.Background(), dirPath, true)
 	if err != nil {
-		return true // Consider it empty if we can't read it
+		dirItems = nil
 	}
-	
-	// Check if directory has any files (not just subdirectories)
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			return false // Found a file
-		}
-	}
-	
-	// If only directories, check if any subdirectory has files
-	for _, entry := range entries {
-		if entry.IsDir() {
-			subPath := filepath.Join(dirPath, entry.Name())
-			if !isDirEmpty(subPath) {
-				return false // Found files in subdirectory
-			}
-		}
-	}
-	
-	return true // No files found anywhere
+	return toListItems(prependParentEntry(dirPath, dirItems))
 }
 
 func convertToDirectoryItems(items []list.Item) []directoryItem {
@@ -489,20 +516,50 @@ func convertToDirectoryItems(items []list.Item) []directoryItem {
 	return dirItems
 }
 
+// filterDirectories matches dirs against filter, which may carry a prefix
+// selecting the match mode: "/re:pattern/" compiles pattern as a regexp,
+// "glob:pattern" matches via filepath.Match, and anything else falls back to
+// a plain case-insensitive substring match.
 func filterDirectories(dirs []directoryItem, filter string) []directoryItem {
 	if filter == "" {
 		return dirs
 	}
-	
+
+	if strings.HasPrefix(filter, "/re:") && strings.HasSuffix(filter, "/") {
+		pattern := filter[len("/re:") : len(filter)-1]
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return dirs // Still typing an invalid pattern; show everything.
+		}
+		var filtered []directoryItem
+		for _, dir := range dirs {
+			if re.MatchString(dir.name) {
+				filtered = append(filtered, dir)
+			}
+		}
+		return filtered
+	}
+
+	if strings.HasPrefix(filter, "glob:") {
+		pattern := filter[len("glob:"):]
+		var filtered []directoryItem
+		for _, dir := range dirs {
+			if matched, err := filepath.Match(pattern, dir.name); err == nil && matched {
+				filtered = append(filtered, dir)
+			}
+		}
+		return filtered
+	}
+
 	filter = strings.ToLower(filter)
 	var filtered []directoryItem
-	
+
 	for _, dir := range dirs {
 		if strings.Contains(strings.ToLower(dir.name), filter) {
 			filtered = append(filtered, dir)
 		}
 	}
-	
+
 	return filtered
 }
 
@@ -517,8 +574,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.directoryList.SetWidth(msg.Width - 6)
+
+		listWidth := msg.Width - 6
+		if m.width > previewSplitWidth {
+			listWidth = int(float64(msg.Width) * 0.4)
+		}
+		m.directoryList.SetWidth(listWidth)
 		m.directoryList.SetHeight(msg.Height - 10)
+		m.bookmarkList.SetWidth(listWidth)
+		m.recentList.SetWidth(listWidth)
+
+		inputWidth := msg.Width - 20
+		if inputWidth < 20 {
+			inputWidth = 20
+		}
+		m.outputInput.Width = inputWidth
+		m.litigantInput.Width = inputWidth
+		m.filterInput.Width = inputWidth
+		m.bookmarkNameInput.Width = inputWidth
+		m.fileOpTextInput.Width = inputWidth
 		return m, nil
 
 	case tea.KeyMsg:
@@ -547,12 +621,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.filtering = false
 					m.filterInput.SetValue("")
 					m.filterInput.Blur()
-					// Reset to show all directories
-					items := getDirectoryItems(m.currentPath)
-					m.directoryList.SetItems(items)
+					// Reset to show all directories already held in the cache
+					m.directoryList.SetItems(toListItems(m.allDirectories))
 					m.directoryList.Select(0) // Reset cursor to first item
-					m.directoryList.Title = fmt.Sprintf("Navigate: %s", m.currentPath)
-					m.allDirectories = convertToDirectoryItems(items)
+					m.directoryList.Title = navTitle(m.currentPath, m.sortField, m.sortAscending, getStrings(m.isSpanish))
 					return m, nil
 				case "enter":
 					// Apply filter and exit filter mode
@@ -593,27 +665,137 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.filtering = true
 					m.filterInput.Focus()
 					m.filterInput.SetValue("")
-					// Store all directories for filtering
-					items := getDirectoryItems(m.currentPath)
 					m.directoryList.Title = fmt.Sprintf("Filter in: %s", m.currentPath)
-					m.allDirectories = convertToDirectoryItems(items)
 					m.filteredDirs = m.allDirectories
 					return m, textinput.Blink
+				case "m":
+					// Bookmark the current directory
+					m.pendingBookmarkDir = m.currentPath
+					m.bookmarkNameInput.SetValue(filepath.Base(m.currentPath))
+					m.bookmarkNameInput.Focus()
+					m.state = namingBookmark
+					return m, textinput.Blink
+				case "'":
+					// Open the bookmark picker
+					m.bookmarkList.SetItems(bookmarkListItems(m.bookmarks))
+					m.bookmarkList.Select(0)
+					m.state = bookmarkPicker
+					return m, nil
+				case "H":
+					// Open the recent-directory picker
+					m.recentList.SetItems(recentListItems(m.recent))
+					m.recentList.Select(0)
+					m.state = recentPicker
+					return m, nil
+				case "s":
+					// Cycle sort field: ModTime -> Name -> Size -> Type
+					if !m.treeMode {
+						m.sortField = nextSortField(m.sortField)
+						m.allDirectories = sortDirItems(m.allDirectories, m.sortField, m.sortAscending)
+						m.directoryList.SetItems(toListItems(m.allDirectories))
+						m.directoryList.Select(0)
+						m.directoryList.Title = navTitle(m.currentPath, m.sortField, m.sortAscending, getStrings(m.isSpanish))
+					}
+					return m, nil
+				case "S":
+					// Reverse sort direction
+					if !m.treeMode {
+						m.sortAscending = !m.sortAscending
+						m.allDirectories = sortDirItems(m.allDirectories, m.sortField, m.sortAscending)
+						m.directoryList.SetItems(toListItems(m.allDirectories))
+						m.directoryList.Select(0)
+						m.directoryList.Title = navTitle(m.currentPath, m.sortField, m.sortAscending, getStrings(m.isSpanish))
+					}
+					return m, nil
+				case "N":
+					// New directory (advanced mode only)
+					if m.advancedMode && !m.treeMode {
+						m.fileOpMode = fileOpCreateDir
+						m.fileOpTextInput.SetValue("")
+						m.fileOpTextInput.Placeholder = "New directory name..."
+						m.fileOpTextInput.Focus()
+						m.state = fileOpInput
+						return m, textinput.Blink
+					}
+				case "R":
+					// Rename highlighted entry (advanced mode only)
+					if m.advancedMode && !m.treeMode {
+						if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok && selected.name != ".." {
+							m.fileOpMode = fileOpRename
+							m.fileOpTarget = selected
+							m.fileOpTextInput.SetValue(selected.name)
+							m.fileOpTextInput.Placeholder = "New name..."
+							m.fileOpTextInput.Focus()
+							m.state = fileOpInput
+							return m, textinput.Blink
+						}
+					}
+				case "D":
+					// Delete highlighted entry, with confirmation (advanced mode only)
+					if m.advancedMode && !m.treeMode {
+						if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok && selected.name != ".." {
+							m.fileOpMode = fileOpDelete
+							m.fileOpTarget = selected
+							m.state = fileOpConfirm
+							return m, nil
+						}
+					}
+				case "M":
+					// Mark highlighted entry for move (advanced mode only)
+					if m.advancedMode && !m.treeMode {
+						if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok && selected.name != ".." {
+							m.markedForMove = selected.path
+						}
+						return m, nil
+					}
+				case "P":
+					// Paste (move) the marked entry into the current directory
+					if m.advancedMode && !m.treeMode && m.markedForMove != "" {
+						movedName := filepath.Base(m.markedForMove)
+						if err := moveEntry(m.markedForMove, m.currentPath); err != nil {
+							m.error = err.Error()
+							m.markedForMove = ""
+							return m, nil
+						}
+						m.markedForMove = ""
+						return m.refreshCurrentDirectory(movedName)
+					}
+				case "t":
+					// Toggle tree view
+					m.treeMode = !m.treeMode
+					if !m.treeMode {
+						m.directoryList.SetItems(toListItems(m.allDirectories))
+						m.directoryList.Select(0)
+						return m, nil
+					}
+					if m.treeRoot == nil || m.treeRoot.path != m.currentPath {
+						m.treeRoot = newTreeRoot(m.currentPath)
+						m.scanning = true
+						return m, tea.Batch(m.scanSpinner.Tick, treeScanCmd(m.currentPath))
+					}
+					m.directoryList.SetItems(treeListItems(m.treeRoot))
+					m.directoryList.Select(0)
+					return m, nil
 			case "enter":
+				if m.treeMode {
+					if selected, ok := m.directoryList.SelectedItem().(treeItem); ok {
+						m.selectedDir = selected.node.path
+						m.recent = withRecent(m.recent, selected.node.path)
+						m.persistState()
+						m.state = selectingOutput
+						m.outputInput.SetValue("index")
+					}
+					return m, nil
+				}
 				if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok {
 					if selected.name == ".." {
 						// Go to parent directory
-						m.currentPath = selected.path
-						// Update directory list and path display
-						items := getDirectoryItems(m.currentPath)
-						m.directoryList.SetItems(items)
-						m.directoryList.Select(0) // Reset cursor to first item
-						m.directoryList.Title = fmt.Sprintf("Navigate: %s", m.currentPath)
-						m.allDirectories = convertToDirectoryItems(items)
-						return m, nil
+						return m.navigateTo(selected.path)
 					} else {
 						// Select the highlighted directory
 						m.selectedDir = selected.path
+						m.recent = withRecent(m.recent, selected.path)
+						m.persistState()
 						m.state = selectingOutput
 						// Use simple "index" filename (xlsx will be auto-appended)
 						m.outputInput.SetValue("index")
@@ -624,47 +806,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Navigate into directory (old enter behavior)
 				if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok {
 					if selected.isDir && selected.name != ".." {
-						m.currentPath = selected.path
-						// Update directory list and path display
-						items := getDirectoryItems(m.currentPath)
-						m.directoryList.SetItems(items)
-						m.directoryList.Select(0) // Reset cursor to first item
-						m.directoryList.Title = fmt.Sprintf("Navigate: %s", m.currentPath)
-						m.allDirectories = convertToDirectoryItems(items)
-						return m, nil
+						return m.navigateTo(selected.path)
 					}
 				}
 			case " ", "space":
 				// Select current directory
 				m.selectedDir = m.currentPath
+				m.recent = withRecent(m.recent, m.currentPath)
+				m.persistState()
 				m.state = selectingOutput
 				// Use simple "index" filename (xlsx will be auto-appended)
 				m.outputInput.SetValue("index")
 				return m, nil
 			case "left":
+				if m.treeMode {
+					if selected, ok := m.directoryList.SelectedItem().(treeItem); ok && selected.node.isDir && selected.node.expanded {
+						selected.node.expanded = false
+						m.directoryList.SetItems(treeListItems(m.treeRoot))
+					}
+					return m, nil
+				}
 				// Go up one directory level
 				parentDir := filepath.Dir(m.currentPath)
 				if parentDir != m.currentPath { // Not at root
-					m.currentPath = parentDir
-					items := getDirectoryItems(m.currentPath)
-					m.directoryList.SetItems(items)
-					m.directoryList.Select(0) // Reset cursor to first item
-					m.directoryList.Title = fmt.Sprintf("Navigate: %s", m.currentPath)
-					m.allDirectories = convertToDirectoryItems(items)
-					return m, nil
+					return m.navigateTo(parentDir)
 				}
 			case "right":
+				if m.treeMode {
+					if selected, ok := m.directoryList.SelectedItem().(treeItem); ok && selected.node.isDir {
+						node := selected.node
+						if !node.loaded {
+							m.scanning = true
+							return m, tea.Batch(m.scanSpinner.Tick, treeScanCmd(node.path))
+						}
+						node.expanded = true
+						m.directoryList.SetItems(treeListItems(m.treeRoot))
+					}
+					return m, nil
+				}
 				// Navigate into highlighted directory
 				if selected, ok := m.directoryList.SelectedItem().(directoryItem); ok {
 					if selected.isDir && selected.name != ".." {
-						m.currentPath = selected.path
-						// Update directory list and path display
-						items := getDirectoryItems(m.currentPath)
-						m.directoryList.SetItems(items)
-						m.directoryList.Select(0) // Reset cursor to first item
-						m.directoryList.Title = fmt.Sprintf("Navigate: %s", m.currentPath)
-						m.allDirectories = convertToDirectoryItems(items)
-						return m, nil
+						return m.navigateTo(selected.path)
 					}
 				}
 			}
@@ -776,8 +959,123 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter", "esc":
 				return m, tea.Quit
 			}
+
+		case namingBookmark:
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc":
+				m.bookmarkNameInput.Blur()
+				m.state = selectingDirectory
+				return m, nil
+			case "enter":
+				label := strings.TrimSpace(m.bookmarkNameInput.Value())
+				if label == "" {
+					label = filepath.Base(m.pendingBookmarkDir)
+				}
+				m.bookmarks = append(m.bookmarks, bookmark{Label: label, Path: m.pendingBookmarkDir})
+				m.persistState()
+				m.bookmarkNameInput.Blur()
+				m.state = selectingDirectory
+				return m, nil
+			default:
+				m.bookmarkNameInput, cmd = m.bookmarkNameInput.Update(msg)
+				return m, cmd
+			}
+
+		case bookmarkPicker:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "b", "esc":
+				m.state = selectingDirectory
+				return m, nil
+			case "enter":
+				if selected, ok := m.bookmarkList.SelectedItem().(directoryItem); ok {
+					m.state = selectingDirectory
+					return m.navigateTo(selected.path)
+				}
+			default:
+				m.bookmarkList, cmd = m.bookmarkList.Update(msg)
+				return m, cmd
+			}
+
+		case recentPicker:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "b", "esc":
+				m.state = selectingDirectory
+				return m, nil
+			case "enter":
+				if selected, ok := m.recentList.SelectedItem().(directoryItem); ok {
+					m.state = selectingDirectory
+					return m.navigateTo(selected.path)
+				}
+			default:
+				m.recentList, cmd = m.recentList.Update(msg)
+				return m, cmd
+			}
+
+		case fileOpInput:
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc":
+				m.fileOpTextInput.Blur()
+				m.fileOpMode = fileOpNone
+				m.state = selectingDirectory
+				return m, nil
+			case "enter":
+				name := strings.TrimSpace(m.fileOpTextInput.Value())
+				mode := m.fileOpMode
+				target := m.fileOpTarget
+				m.fileOpTextInput.Blur()
+				m.fileOpMode = fileOpNone
+				m.state = selectingDirectory
+				if name == "" {
+					return m, nil
+				}
+				switch mode {
+				case fileOpCreateDir:
+					if err := createDirectory(filepath.Join(m.currentPath, name)); err != nil {
+						m.error = err.Error()
+						return m, nil
+					}
+					return m.refreshCurrentDirectory(name)
+				case fileOpRename:
+					if err := renameEntry(target.path, filepath.Join(filepath.Dir(target.path), name)); err != nil {
+						m.error = err.Error()
+						return m, nil
+					}
+					return m.refreshCurrentDirectory(name)
+				}
+				return m, nil
+			default:
+				m.fileOpTextInput, cmd = m.fileOpTextInput.Update(msg)
+				return m, cmd
+			}
+
+		case fileOpConfirm:
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "y", "enter":
+				target := m.fileOpTarget
+				m.fileOpMode = fileOpNone
+				m.state = selectingDirectory
+				if err := deleteEntry(target.path); err != nil {
+					m.error = err.Error()
+					return m, nil
+				}
+				return m.refreshCurrentDirectory("")
+			case "n", "esc":
+				m.fileOpMode = fileOpNone
+				m.state = selectingDirectory
+				return m, nil
+			}
 		}
-	
+
 	case processCompleteMsg:
 		m.processing = false
 		if msg.error != "" {
@@ -786,6 +1084,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.result = msg.result
 		}
 		m.state = finished
+		m.persistPrefs()
+		return m, nil
+
+	case directoryScannedMsg:
+		if msg.gen != m.scanGen {
+			return m, nil // Stale scan for a path we've since navigated away from.
+		}
+		m.scanning = false
+		m.scanCancel = nil
+		if msg.err != nil {
+			return m, nil // Cancelled or unreadable; leave the list as-is.
+		}
+		m.dirCache.set(msg.path, dirScanResult{items: msg.items, mtime: time.Now()})
+		items := prependParentEntry(msg.path, msg.items)
+		items = sortDirItems(items, m.sortField, m.sortAscending)
+		m.allDirectories = items
+		m.directoryList.SetItems(toListItems(items))
+		m.directoryList.Select(0)
+		return m.startPreview()
+
+	case treeScannedMsg:
+		m.scanning = false
+		if node := findTreeNode(m.treeRoot, msg.path); node != nil && msg.err == nil {
+			node.loaded = true
+			node.expanded = true
+			node.children = buildTreeChildren(node, msg.items)
+		}
+		if m.treeMode {
+			m.directoryList.SetItems(treeListItems(m.treeRoot))
+			m.directoryList.Select(0)
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.scanning || m.previewLoading {
+			m.scanSpinner, cmd = m.scanSpinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case previewSummaryMsg:
+		if msg.gen != m.previewGen {
+			return m, nil // Stale: the cursor moved on before this summary returned.
+		}
+		m.previewLoading = false
+		m.previewCancel = nil
+		if msg.err != nil {
+			return m, nil
+		}
+		m.previewCache[msg.path] = msg.summary
+		if msg.path == m.previewPath {
+			m.previewSummary = msg.summary
+		}
 		return m, nil
 	}
 
@@ -798,6 +1149,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "up", "down", "j", "k":
 				// Pass these keys for list navigation (up/down selection)
 				m.directoryList, cmd = m.directoryList.Update(msg)
+				if m.width > previewSplitWidth && !m.treeMode && !m.filtering {
+					var previewCmd tea.Cmd
+					m, previewCmd = m.startPreview()
+					cmd = tea.Batch(cmd, previewCmd)
+				}
 			case "left", "right":
 				// Don't pass left/right to list - we handle them ourselves
 				// No action needed, already handled above
@@ -847,18 +1203,65 @@ func (m model) View() string {
 				str.filterPrompt + "\n\n" +
 				m.filterInput.View() + "\n\n" +
 				m.directoryList.View() + "\n\n" +
-				str.filterControls))
+				str.filterControls + "\n" +
+				str.filterSyntaxHint))
 		} else {
+			listView := m.directoryList.View()
+			if m.scanning {
+				listView = fmt.Sprintf("%s Scanning...", m.scanSpinner.View())
+			}
 			// Don't override the title here - it's already set in navigation logic
-			content.WriteString(dynamicActiveBoxStyle.Render(
+			left := dynamicActiveBoxStyle.Render(
 				str.step1Title + "\n\n" +
-				m.directoryList.View() + "\n\n" +
+				listView + "\n\n" +
 				str.navigation + "\n" +
 				"  " + str.browseDirectories + "    " + str.selectFolder + "\n" +
 				"  " + str.leftRight + "  " + str.selectCurrent + "\n" +
-				"  " + str.filterDirectories + "  " + str.advancedMode))
+				"  " + str.filterDirectories + "  " + str.advancedMode + "\n" +
+				"  m = Bookmark current    ' = Bookmarks    H = Recent\n" +
+				"  t = Toggle tree view  (tree: →/← expand/collapse, Enter selects)\n" +
+				"  " + str.sortControls +
+				fileOpsHint(m.advancedMode))
+
+			if m.width > previewSplitWidth && !m.treeMode {
+				content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, m.renderPreviewPane()))
+			} else {
+				content.WriteString(left)
+			}
 		}
 
+	case namingBookmark:
+		content.WriteString(dynamicActiveBoxStyle.Render(
+			fmt.Sprintf("Bookmark: %s\n\n", m.pendingBookmarkDir) +
+				m.bookmarkNameInput.View() + "\n\n" +
+				"Enter to save, Esc to cancel"))
+
+	case bookmarkPicker:
+		content.WriteString(dynamicActiveBoxStyle.Render(
+			"Bookmarks\n\n" +
+				m.bookmarkList.View() + "\n\n" +
+				"Enter to jump, B/Esc to go back"))
+
+	case recentPicker:
+		content.WriteString(dynamicActiveBoxStyle.Render(
+			"Recent Directories\n\n" +
+				m.recentList.View() + "\n\n" +
+				"Enter to jump, B/Esc to go back"))
+
+	case fileOpInput:
+		label := "New directory name:"
+		if m.fileOpMode == fileOpRename {
+			label = fmt.Sprintf("Rename %s to:", m.fileOpTarget.name)
+		}
+		content.WriteString(dynamicActiveBoxStyle.Render(
+			label + "\n\n" +
+				m.fileOpTextInput.View() + "\n\n" +
+				"Enter to confirm, Esc to cancel"))
+
+	case fileOpConfirm:
+		content.WriteString(boxStyle.Render(
+			fmt.Sprintf("Delete %s?\n\nThis cannot be undone.\n\ny/Enter = Delete    n/Esc = Cancel", m.fileOpTarget.name)))
+
 	case selectingOutput:
 		content.WriteString(dynamicBoxStyle.Render(
 			fmt.Sprintf("Selected: %s", m.selectedDir)))
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// previewSplitWidth is the terminal width above which selectingDirectory
+// splits into a directory list pane and a preview pane, mirroring
+// DirectoryContentsBrowser's previewIndex concept.
+const previewSplitWidth = 120
+
+// dirSummary is what computeDirSummary produces for the highlighted
+// directory: a file count broken down by extension plus total size and
+// mtime range, cached in model.previewCache keyed by path.
+type dirSummary struct {
+	fileCount   int
+	byExtension map[string]int
+	totalSize   int64
+	newest      time.Time
+	oldest      time.Time
+}
+
+// previewSummaryMsg is delivered when a background preview computation
+// finishes. gen mirrors directoryScannedMsg's staleness check: the user may
+// have moved the cursor on before a slow summary of a huge folder returns.
+type previewSummaryMsg struct {
+	path    string
+	gen     int
+	summary dirSummary
+	err     error
+}
+
+// computeDirSummary walks path recursively, checking ctx between entries so
+// highlighting a huge folder can be cancelled by moving the cursor on
+// instead of stalling navigation.
+func computeDirSummary(ctx context.Context, path string) (dirSummary, error) {
+	summary := dirSummary{byExtension: make(map[string]int)}
+
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries rather than aborting the whole summary.
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		summary.fileCount++
+		summary.totalSize += info.Size()
+
+		ext := strings.ToLower(filepath.Ext(d.Name()))
+		if ext == "" {
+			ext = "(none)"
+		}
+		summary.byExtension[ext]++
+
+		mtime := info.ModTime()
+		if summary.newest.IsZero() || mtime.After(summary.newest) {
+			summary.newest = mtime
+		}
+		if summary.oldest.IsZero() || mtime.Before(summary.oldest) {
+			summary.oldest = mtime
+		}
+		return nil
+	})
+
+	return summary, err
+}
+
+// previewSummaryCmd runs computeDirSummary in the background and reports the
+// result as a previewSummaryMsg.
+func previewSummaryCmd(ctx context.Context, path string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := computeDirSummary(ctx, path)
+		return previewSummaryMsg{path: path, gen: gen, summary: summary, err: err}
+	}
+}
+
+// startPreview kicks off (or serves from cache) a summary of the currently
+// highlighted directory, cancelling any summary still being computed for a
+// previously highlighted one. It shares the same cancel/generation pattern
+// navigateTo uses for the main scan so the two never race each other's
+// staleness checks.
+func (m model) startPreview() (model, tea.Cmd) {
+	selected, ok := m.directoryList.SelectedItem().(directoryItem)
+	if !ok || !selected.isDir {
+		return m, nil
+	}
+	if selected.path == m.previewPath && !m.previewLoading {
+		return m, nil
+	}
+	if m.previewCancel != nil {
+		m.previewCancel()
+	}
+	m.previewPath = selected.path
+
+	if summary, ok := m.previewCache[selected.path]; ok {
+		m.previewSummary = summary
+		m.previewLoading = false
+		m.previewCancel = nil
+		return m, nil
+	}
+
+	m.previewGen++
+	gen := m.previewGen
+	ctx, cancel := context.WithCancel(context.Background())
+	m.previewCancel = cancel
+	m.previewLoading = true
+	return m, tea.Batch(m.scanSpinner.Tick, previewSummaryCmd(ctx, selected.path, gen))
+}
+
+// renderPreviewPane renders the right-hand pane shown when the terminal is
+// wider than previewSplitWidth.
+func (m model) renderPreviewPane() string {
+	if m.previewPath == "" {
+		return boxStyle.Render("Preview\n\nHighlight a directory to see its summary.")
+	}
+	if m.previewLoading {
+		return boxStyle.Render(fmt.Sprintf("Preview: %s\n\n%s Scanning...", filepath.Base(m.previewPath), m.scanSpinner.View()))
+	}
+
+	s := m.previewSummary
+	var b strings.Builder
+	fmt.Fprintf(&b, "Preview: %s\n\n", filepath.Base(m.previewPath))
+	fmt.Fprintf(&b, "Files: %d\n", s.fileCount)
+	fmt.Fprintf(&b, "Total size: %s\n", formatByteSize(s.totalSize))
+	if !s.newest.IsZero() {
+		fmt.Fprintf(&b, "Newest: %s\n", s.newest.Format("2006-01-02 15:04"))
+		fmt.Fprintf(&b, "Oldest: %s\n", s.oldest.Format("2006-01-02 15:04"))
+	}
+	if len(s.byExtension) > 0 {
+		b.WriteString("\nBy extension:\n")
+		exts := make([]string, 0, len(s.byExtension))
+		for ext := range s.byExtension {
+			exts = append(exts, ext)
+		}
+		sort.Strings(exts)
+		for _, ext := range exts {
+			fmt.Fprintf(&b, "  %-10s %d\n", ext, s.byExtension[ext])
+		}
+	}
+
+	return boxStyle.Render(b.String())
+}
+
+// formatByteSize renders n using binary (KiB/MiB/...) units.
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bblist "github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxEmptyCheckDepth bounds how far isDirEmpty recurses before giving up and
+// assuming the directory has content. Unbounded recursion was the dominant
+// cost when navigating directories with tens of thousands of files.
+const maxEmptyCheckDepth = 3
+
+// dirCacheTTL-less LRU of scanned directories, keyed by path. Entries are
+// invalidated when the directory's mtime moves past what was cached.
+const dirCacheCapacity = 64
+
+// dirScanResult is what a background scan produces for a single directory.
+type dirScanResult struct {
+	items []directoryItem
+	mtime time.Time
+}
+
+// dirCache is a small LRU so repeat navigation (e.g. going back up and down
+// the same tree) doesn't re-walk the filesystem.
+type dirCache struct {
+	capacity int
+	ll       *list.List
+	entries  map[string]*list.Element
+}
+
+type dirCacheEntry struct {
+	path   string
+	result dirScanResult
+}
+
+func newDirCache(capacity int) *dirCache {
+	return &dirCache{
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *dirCache) get(path string) (dirScanResult, bool) {
+	el, ok := c.entries[path]
+	if !ok {
+		return dirScanResult{}, false
+	}
+	entry := el.Value.(*dirCacheEntry)
+	// Invalidate if the directory changed since we cached it.
+	if info, err := os.Stat(path); err == nil && info.ModTime().After(entry.result.mtime) {
+		c.ll.Remove(el)
+		delete(c.entries, path)
+		return dirScanResult{}, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.result, true
+}
+
+func (c *dirCache) set(path string, result dirScanResult) {
+	if el, ok := c.entries[path]; ok {
+		el.Value.(*dirCacheEntry).result = result
+		c.ll.MoveToFront(el)
+		return
+	}
+	el := c.ll.PushFront(&dirCacheEntry{path: path, result: result})
+	c.entries[path] = el
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.entries, oldest.Value.(*dirCacheEntry).path)
+		}
+	}
+}
+
+// prependParentEntry adds the ".." entry used to climb back to dirPath's
+// parent, matching the layout the flat list has always shown.
+func prependParentEntry(dirPath string, items []directoryItem) []directoryItem {
+	parent := filepath.Dir(dirPath)
+	if parent == dirPath {
+		return items
+	}
+	withParent := make([]directoryItem, 0, len(items)+1)
+	withParent = append(withParent, directoryItem{name: "..", path: parent, isDir: true})
+	withParent = append(withParent, items...)
+	return withParent
+}
+
+func toListItems(items []directoryItem) []bblist.Item {
+	listItems := make([]bblist.Item, len(items))
+	for i, it := range items {
+		listItems[i] = it
+	}
+	return listItems
+}
+
+// navigateTo cancels any in-flight scan, consults the LRU cache, and either
+// updates the list immediately (cache hit) or kicks off a background scan
+// that reports back via directoryScannedMsg while the list shows a spinner.
+func (m model) navigateTo(path string) (model, tea.Cmd) {
+	if m.scanCancel != nil {
+		m.scanCancel()
+	}
+	m.scanGen++
+	gen := m.scanGen
+	m.currentPath = path
+	m.directoryList.Title = navTitle(path, m.sortField, m.sortAscending, getStrings(m.isSpanish))
+
+	if result, ok := m.dirCache.get(path); ok {
+		m.scanning = false
+		m.scanCancel = nil
+		items := prependParentEntry(path, result.items)
+		items = sortDirItems(items, m.sortField, m.sortAscending)
+		m.allDirectories = items
+		m.directoryList.SetItems(toListItems(items))
+		m.directoryList.Select(0)
+		m, previewCmd := m.startPreview()
+		return m, previewCmd
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.scanCancel = cancel
+	m.scanning = true
+	return m, tea.Batch(m.scanSpinner.Tick, scanDirectoryCmd(ctx, path, gen, m.hideEmptyDirs))
+}
+
+// directoryScannedMsg is delivered when a background scan finishes. gen lets
+// Update discard results for stale navigation (the user moved on before the
+// scan returned).
+type directoryScannedMsg struct {
+	path  string
+	gen   int
+	items []directoryItem
+	err   error
+}
+
+// scanDirectoryCmd reads dirPath in the background and reports the result as
+// a directoryScannedMsg, honoring ctx cancellation so navigating away drops
+// the in-flight scan instead of racing it.
+func scanDirectoryCmd(ctx context.Context, dirPath string, gen int, hideEmptyDirs bool) tea.Cmd {
+	return func() tea.Msg {
+		items, err := readDirectoryItems(ctx, dirPath, hideEmptyDirs)
+		if err != nil {
+			return directoryScannedMsg{path: dirPath, gen: gen, err: err}
+		}
+		return directoryScannedMsg{path: dirPath, gen: gen, items: items}
+	}
+}
+
+// readDirectoryItems does the actual work previously done inline in
+// getDirectoryItems, but checks ctx between entries so a cancelled scan of a
+// huge directory returns promptly instead of finishing the walk anyway.
+func readDirectoryItems(ctx context.Context, dirPath string, hideEmptyDirs bool) ([]directoryItem, error) {
+	var dirItems []directoryItem
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if name == "" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if len(strings.TrimSpace(name)) == 0 {
+			continue
+		}
+
+		fullPath := filepath.Join(dirPath, name)
+		info, err := entry.Info()
+		var modTime time.Time
+		var size int64
+		if err == nil {
+			modTime = info.ModTime()
+			size = info.Size()
+		}
+
+		if hideEmptyDirs && isDirEmptyBounded(fullPath, maxEmptyCheckDepth) {
+			continue
+		}
+
+		dirItems = append(dirItems, directoryItem{
+			name:    name,
+			path:    fullPath,
+			isDir:   true,
+			modTime: modTime,
+			size:    size,
+		})
+	}
+
+	// Sorting is applied at display time based on the model's current sort
+	// mode (see sortmode.go); readDirectoryItems just returns os.ReadDir's
+	// natural name order here.
+	return dirItems, nil
+}
+
+// isDirEmptyBounded replaces the old unbounded recursive isDirEmpty. It only
+// looks maxDepth levels deep; beyond that it assumes the directory has
+// content rather than paying for a full subtree walk. This is the "hide
+// empty dirs" toggle's cost bound, not a precise answer.
+func isDirEmptyBounded(dirPath string, maxDepth int) bool {
+	if maxDepth < 0 {
+		return false
+	}
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return true // Consider it empty if we can't read it.
+	}
+
+	var subdirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			return false // Found a file.
+		}
+		subdirs = append(subdirs, entry.Name())
+	}
+
+	if maxDepth == 0 {
+		// Only directories at this level and we've hit the depth bound:
+		// assume there's content further down rather than recursing.
+		return len(subdirs) == 0
+	}
+
+	for _, name := range subdirs {
+		if !isDirEmptyBounded(filepath.Join(dirPath, name), maxDepth-1) {
+			return false
+		}
+	}
+
+	return true
+}
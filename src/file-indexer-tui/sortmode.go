@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortField selects which directoryItem attribute the flat directory list is
+// ordered by, cycled with 's' and reversed with 'S' in selectingDirectory.
+type sortField int
+
+const (
+	sortByModTime sortField = iota
+	sortByName
+	sortBySize
+	sortByType
+)
+
+// nextSortField cycles ModTime -> Name -> Size -> Type -> ModTime, mirroring
+// the classic sortByWhat option cycle from the Smalltalk FileBrowser.
+func nextSortField(f sortField) sortField {
+	switch f {
+	case sortByModTime:
+		return sortByName
+	case sortByName:
+		return sortBySize
+	case sortBySize:
+		return sortByType
+	default:
+		return sortByModTime
+	}
+}
+
+// sortFieldLabel returns the bilingual label for field shown in the list
+// title and help text.
+func sortFieldLabel(field sortField, strs langStrings) string {
+	switch field {
+	case sortByName:
+		return strs.sortByName
+	case sortBySize:
+		return strs.sortBySize
+	case sortByType:
+		return strs.sortByType
+	default:
+		return strs.sortByDate
+	}
+}
+
+// navTitle builds the directoryList title shown while browsing a directory,
+// including the active sort mode so toggling 's'/'S' has visible feedback.
+func navTitle(path string, field sortField, ascending bool, strs langStrings) string {
+	arrow := "↓"
+	if ascending {
+		arrow = "↑"
+	}
+	return fmt.Sprintf("Navigate: %s [%s %s]", path, sortFieldLabel(field, strs), arrow)
+}
+
+// sortDirItems orders dirs by field, always pinning a ".." entry first
+// regardless of direction so climbing back up stays reachable at the top.
+func sortDirItems(dirs []directoryItem, field sortField, ascending bool) []directoryItem {
+	sorted := make([]directoryItem, len(dirs))
+	copy(sorted, dirs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.name == ".." {
+			return true
+		}
+		if b.name == ".." {
+			return false
+		}
+
+		var less bool
+		switch field {
+		case sortByName:
+			less = strings.ToLower(a.name) < strings.ToLower(b.name)
+		case sortBySize:
+			less = a.size < b.size
+		case sortByType:
+			less = !a.isDir && b.isDir
+		default: // sortByModTime
+			less = a.modTime.Before(b.modTime)
+		}
+		if !ascending {
+			return !less
+		}
+		return less
+	})
+
+	return sorted
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	bblist "github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// treeNode is one entry in the expandable directory tree shown by tree
+// view. Nodes are held by pointer so expand/collapse state survives the
+// model being copied by value between Update calls, the same way dirCache
+// does for the async scan cache.
+type treeNode struct {
+	path     string
+	name     string
+	depth    int
+	isDir    bool
+	expanded bool
+	loaded   bool
+	children []*treeNode
+}
+
+// newTreeRoot starts a tree rooted at dirPath; the root itself is never
+// shown as a row, only its (lazily loaded) children are.
+func newTreeRoot(dirPath string) *treeNode {
+	return &treeNode{path: dirPath, name: filepath.Base(dirPath), isDir: true, expanded: true}
+}
+
+// findTreeNode does a depth-first search for path, used to locate the node
+// a treeScannedMsg's results belong to.
+func findTreeNode(n *treeNode, path string) *treeNode {
+	if n.path == path {
+		return n
+	}
+	for _, c := range n.children {
+		if found := findTreeNode(c, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// buildTreeChildren turns a directory scan's items into child treeNodes,
+// dropping the synthetic ".." entry since collapsing already gets you back
+// to the parent in tree view.
+func buildTreeChildren(parent *treeNode, items []directoryItem) []*treeNode {
+	children := make([]*treeNode, 0, len(items))
+	for _, it := range items {
+		if it.name == ".." {
+			continue
+		}
+		children = append(children, &treeNode{
+			path:  it.path,
+			name:  it.name,
+			depth: parent.depth + 1,
+			isDir: it.isDir,
+		})
+	}
+	return children
+}
+
+// flattenTree walks the tree depth-first, descending into a node's
+// children only when it's expanded, producing the rows tree view renders.
+func flattenTree(root *treeNode) []*treeNode {
+	var out []*treeNode
+	var walk func(n *treeNode)
+	walk = func(n *treeNode) {
+		out = append(out, n)
+		if n.expanded {
+			for _, c := range n.children {
+				walk(c)
+			}
+		}
+	}
+	for _, c := range root.children {
+		walk(c)
+	}
+	return out
+}
+
+// treeListItems adapts the currently-visible tree nodes to list.Item so
+// they can be shown in the existing directoryList widget.
+func treeListItems(root *treeNode) []bblist.Item {
+	nodes := flattenTree(root)
+	items := make([]bblist.Item, len(nodes))
+	for i, n := range nodes {
+		items[i] = treeItem{node: n}
+	}
+	return items
+}
+
+// treeItem renders a treeNode indented by depth with an expand/collapse
+// arrow, so the flat list.Model can display it like an indented tree.
+type treeItem struct {
+	node *treeNode
+}
+
+func (t treeItem) Title() string {
+	indent := strings.Repeat("  ", t.node.depth)
+	icon := "📄"
+	arrow := "  "
+	if t.node.isDir {
+		icon = "📁"
+		switch {
+		case !t.node.loaded:
+			arrow = "▸ "
+		case t.node.expanded:
+			arrow = "▾ "
+		default:
+			arrow = "▸ "
+		}
+	}
+	return fmt.Sprintf("%s%s%s %s", indent, arrow, icon, t.node.name)
+}
+
+func (t treeItem) Description() string {
+	return ""
+}
+
+func (t treeItem) FilterValue() string {
+	return t.node.name
+}
+
+// treeScannedMsg is delivered when a background scan triggered by expanding
+// a tree node finishes.
+type treeScannedMsg struct {
+	path  string
+	items []directoryItem
+	err   error
+}
+
+// treeScanCmd reads dirPath in the background for tree view; unlike
+// navigateTo's scanDirectoryCmd it isn't subject to the flat view's
+// generation-based cancellation since expanding one node never invalidates
+// another node's in-flight scan.
+func treeScanCmd(dirPath string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := readDirectoryItems(context.Background(), dirPath, false)
+		return treeScannedMsg{path: dirPath, items: items, err: err}
+	}
+}
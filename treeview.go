@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// dirTreeNode is one entry in the expandable directory tree shown by
+// selectingDirectory, mirroring src/file-indexer-tui's treeNode. Nodes are
+// held by pointer so expand/collapse state survives the model being copied
+// by value between Update calls.
+type dirTreeNode struct {
+	path     string
+	name     string
+	depth    int
+	isDir    bool
+	expanded bool
+	loaded   bool
+	isRecent bool // true for a synthetic row from recentDirTreeNodes, shown with a star instead of the usual folder icon
+	parent   *dirTreeNode
+	children []*dirTreeNode
+}
+
+// newDirTreeRoot starts a tree rooted at dirPath; the root itself is never
+// shown as a row, only its (lazily loaded) children are.
+func newDirTreeRoot(dirPath string) *dirTreeNode {
+	return &dirTreeNode{path: dirPath, name: filepath.Base(dirPath), isDir: true, expanded: true}
+}
+
+// ExpandFolder loads node's children from dirCache (populating the cache on
+// first expansion) and marks it expanded, so repeated expand/collapse of an
+// already-visited directory is instant.
+func (m *model) ExpandFolder(node *dirTreeNode) {
+	if !node.isDir {
+		return
+	}
+	if !node.loaded {
+		entries, ok := m.dirCache[node.path]
+		if !ok {
+			entries = listDirEntries(node.path)
+			m.dirCache[node.path] = entries
+		}
+		node.children = buildDirTreeChildren(node, entries)
+		node.loaded = true
+	}
+	node.expanded = true
+}
+
+// CollapseFolder marks node collapsed without discarding its children, so
+// ExpandFolder can re-show them instantly without rescanning.
+func (m *model) CollapseFolder(node *dirTreeNode) {
+	node.expanded = false
+}
+
+// buildDirTreeChildren turns a cached directory listing into child
+// dirTreeNodes, dropping the synthetic ".." entry since collapsing already
+// gets you back to the parent in tree view.
+func buildDirTreeChildren(parent *dirTreeNode, entries []directoryItem) []*dirTreeNode {
+	children := make([]*dirTreeNode, 0, len(entries))
+	for _, e := range entries {
+		if e.name == ".." {
+			continue
+		}
+		children = append(children, &dirTreeNode{
+			path:   e.path,
+			name:   e.name,
+			depth:  parent.depth + 1,
+			isDir:  e.isDir,
+			parent: parent,
+		})
+	}
+	return children
+}
+
+// maxRecentDirTreeNodes bounds how many recent directories are shown as
+// synthetic rows at the top of the initial tree view, matching
+// file-indexer-tui's maxRecentInHomeList.
+const maxRecentDirTreeNodes = 3
+
+// recentDirTreeNodes turns recent (most-recent first, as persisted in
+// config.Prefs.RecentDirs) into synthetic top-level dirTreeNodes meant to be
+// prepended ahead of root's real children, each validated with os.Stat so a
+// deleted or unmounted directory doesn't show up as a dead entry. They
+// behave like any other directory node once expanded or selected; isRecent
+// only changes how they're drawn.
+func recentDirTreeNodes(recent []string, root *dirTreeNode) []*dirTreeNode {
+	var nodes []*dirTreeNode
+	for _, path := range recent {
+		if len(nodes) >= maxRecentDirTreeNodes {
+			break
+		}
+		if path == root.path {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		nodes = append(nodes, &dirTreeNode{
+			path:     path,
+			name:     filepath.Base(path),
+			depth:    root.depth + 1,
+			isDir:    true,
+			isRecent: true,
+			parent:   root,
+		})
+	}
+	return nodes
+}
+
+// flattenDirTree walks the tree depth-first, descending into a node's
+// children only when it's expanded, producing the rows tree view renders.
+func flattenDirTree(root *dirTreeNode) []*dirTreeNode {
+	var out []*dirTreeNode
+	var walk func(n *dirTreeNode)
+	walk = func(n *dirTreeNode) {
+		out = append(out, n)
+		if n.expanded {
+			for _, c := range n.children {
+				walk(c)
+			}
+		}
+	}
+	for _, c := range root.children {
+		walk(c)
+	}
+	return out
+}
+
+// dirTreeListItems adapts the currently-visible tree nodes to list.Item so
+// they can be shown in the existing directoryList widget.
+func dirTreeListItems(root *dirTreeNode) []list.Item {
+	nodes := flattenDirTree(root)
+	items := make([]list.Item, len(nodes))
+	for i, n := range nodes {
+		items[i] = dirTreeItem{node: n}
+	}
+	return items
+}
+
+// dirTreeItem renders a dirTreeNode indented by depth with an
+// expand/collapse arrow, so the flat list.Model can display it like an
+// indented tree. Filtering ("/") spans every currently-expanded subtree
+// since it operates on whatever dirTreeListItems currently returned.
+type dirTreeItem struct {
+	node *dirTreeNode
+}
+
+func (t dirTreeItem) Title() string {
+	indent := strings.Repeat("  ", t.node.depth)
+	icon := "📄"
+	arrow := "  "
+	if t.node.isDir {
+		icon = "📁"
+		if t.node.isRecent {
+			icon = "⭐"
+		}
+		if t.node.expanded {
+			arrow = "▾ "
+		} else {
+			arrow = "▸ "
+		}
+	}
+	return fmt.Sprintf("%s%s%s %s", indent, arrow, icon, t.node.name)
+}
+
+func (t dirTreeItem) Description() string {
+	if t.node.isRecent {
+		return "Recent"
+	}
+	if t.node.isDir {
+		return "Directory"
+	}
+	return "File"
+}
+
+func (t dirTreeItem) FilterValue() string {
+	return t.node.name
+}
+
+// selectDirTreeNode moves list's cursor to target's row within root's
+// current flattened view, used when collapsing a node's parent leaves the
+// previous selection hidden.
+func selectDirTreeNode(list *list.Model, root, target *dirTreeNode) {
+	for i, n := range flattenDirTree(root) {
+		if n == target {
+			list.Select(i)
+			return
+		}
+	}
+}
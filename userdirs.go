@@ -0,0 +1,38 @@
+package main
+
+import "os"
+
+// userDirCandidates returns the platform-appropriate Downloads, Documents,
+// Desktop, and home directories, in the order the 'tab' quick-jump shortcut
+// should hop through them. It doesn't check whether each entry actually
+// exists on disk — callers decide what to do with a missing one, the same
+// way getDownloadsDirectory already falls through its candidate list.
+func userDirCandidates() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return []string{"."}
+	}
+
+	dirs := []string{
+		platformDownloadsDir(home),
+		platformDocumentsDir(home),
+		platformDesktopDir(home),
+		home,
+	}
+	if icloud := platformICloudDir(home); icloud != "" {
+		dirs = append(dirs, icloud)
+	}
+	return dirs
+}
+
+// getDownloadsDirectory returns the first existing directory from
+// userDirCandidates, falling back to "." if none of them exist (or
+// os.UserHomeDir itself failed).
+func getDownloadsDirectory() string {
+	for _, dir := range userDirCandidates() {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return "."
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func platformDownloadsDir(home string) string {
+	return filepath.Join(home, "Downloads")
+}
+
+func platformDocumentsDir(home string) string {
+	return filepath.Join(home, "Documents")
+}
+
+func platformDesktopDir(home string) string {
+	return filepath.Join(home, "Desktop")
+}
+
+// platformICloudDir returns the local mount point for iCloud Drive, or ""
+// if this Mac doesn't have it set up.
+func platformICloudDir(home string) string {
+	dir := filepath.Join(home, "Library", "Mobile Documents", "com~apple~CloudDocs")
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir
+	}
+	return ""
+}
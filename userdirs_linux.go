@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// xdgDirLine matches a line from ~/.config/user-dirs.dirs, the file
+// xdg-user-dirs-update writes. The value is usually $HOME-relative, e.g.:
+//
+//	XDG_DOWNLOAD_DIR="$HOME/Téléchargements"
+//
+// but once a user relocates a folder outside $HOME (xdg-user-dirs-update's
+// normal behavior after e.g. moving Downloads to another drive), the value
+// is a bare absolute path instead:
+//
+//	XDG_DOWNLOAD_DIR="/mnt/data/Downloads"
+//
+// so the value is captured as-is and left for expandXDGHome to resolve
+// either form.
+var xdgDirLine = regexp.MustCompile(`^XDG_(\w+)_DIR="?([^"]*)"?$`)
+
+func platformDownloadsDir(home string) string {
+	if dir := xdgUserDir("DOWNLOAD"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_DOWNLOAD_DIR"); dir != "" {
+		return expandXDGHome(dir, home)
+	}
+	return filepath.Join(home, "Downloads")
+}
+
+func platformDocumentsDir(home string) string {
+	if dir := xdgUserDir("DOCUMENTS"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_DOCUMENTS_DIR"); dir != "" {
+		return expandXDGHome(dir, home)
+	}
+	return filepath.Join(home, "Documents")
+}
+
+func platformDesktopDir(home string) string {
+	if dir := xdgUserDir("DESKTOP"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_DESKTOP_DIR"); dir != "" {
+		return expandXDGHome(dir, home)
+	}
+	return filepath.Join(home, "Desktop")
+}
+
+// platformICloudDir has no Linux equivalent.
+func platformICloudDir(home string) string {
+	return ""
+}
+
+// xdgUserDir parses $XDG_CONFIG_HOME/user-dirs.dirs for XDG_<kind>_DIR,
+// returning "" if the file doesn't exist or has no entry for kind — callers
+// fall back to the env var and then a hardcoded default, the same tolerant
+// chain getDownloadsDirectory already used before this file existed.
+func xdgUserDir(kind string) string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(filepath.Join(configDir, "user-dirs.dirs"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := xdgDirLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m != nil && m[1] == kind {
+			return expandXDGHome(m[2], home)
+		}
+	}
+	return ""
+}
+
+// expandXDGHome resolves a $HOME-relative path from an XDG_*_DIR env var.
+func expandXDGHome(path, home string) string {
+	if strings.HasPrefix(path, "$HOME") {
+		return filepath.Join(home, strings.TrimPrefix(path, "$HOME"))
+	}
+	return path
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXdgDirLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantKind string
+		wantVal  string
+		wantOK   bool
+	}{
+		{"home_relative", `XDG_DOWNLOAD_DIR="$HOME/Downloads"`, "DOWNLOAD", "$HOME/Downloads", true},
+		{"home_relative_unicode", `XDG_DOWNLOAD_DIR="$HOME/Téléchargements"`, "DOWNLOAD", "$HOME/Téléchargements", true},
+		{"relocated_absolute_path", `XDG_DOWNLOAD_DIR="/mnt/data/Downloads"`, "DOWNLOAD", "/mnt/data/Downloads", true},
+		{"unquoted", `XDG_DESKTOP_DIR=$HOME/Desktop`, "DESKTOP", "$HOME/Desktop", true},
+		{"comment_line", `# XDG_DOWNLOAD_DIR="$HOME/Downloads"`, "", "", false},
+		{"unrelated_line", `some_other_setting=1`, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := xdgDirLine.FindStringSubmatch(tt.line)
+			if !tt.wantOK {
+				if m != nil {
+					t.Fatalf("xdgDirLine matched %q unexpectedly: %v", tt.line, m)
+				}
+				return
+			}
+			if m == nil {
+				t.Fatalf("xdgDirLine did not match %q", tt.line)
+			}
+			if m[1] != tt.wantKind || m[2] != tt.wantVal {
+				t.Errorf("xdgDirLine.FindStringSubmatch(%q) = kind %q, value %q, want kind %q, value %q", tt.line, m[1], m[2], tt.wantKind, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestExpandXDGHome(t *testing.T) {
+	home := "/home/alice"
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"home_relative", "$HOME/Downloads", filepath.Join(home, "/Downloads")},
+		{"bare_home", "$HOME", home},
+		{"already_absolute", "/mnt/data/Downloads", "/mnt/data/Downloads"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandXDGHome(tt.path, home); got != tt.want {
+				t.Errorf("expandXDGHome(%q, %q) = %q, want %q", tt.path, home, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestXdgUserDirRelocated exercises xdgUserDir end-to-end against a
+// user-dirs.dirs written as xdg-user-dirs-update leaves it after a folder is
+// relocated outside $HOME, the scenario this regex was fixed for.
+func TestXdgUserDirRelocated(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", t.TempDir())
+
+	content := "XDG_DOWNLOAD_DIR=\"/mnt/data/Downloads\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "user-dirs.dirs"), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got := xdgUserDir("DOWNLOAD")
+	want := "/mnt/data/Downloads"
+	if got != want {
+		t.Errorf("xdgUserDir(\"DOWNLOAD\") = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+func platformDownloadsDir(home string) string {
+	if path, err := windows.KnownFolderPath(windows.FOLDERID_Downloads, windows.KF_FLAG_DEFAULT); err == nil {
+		return path
+	}
+	return filepath.Join(home, "Downloads")
+}
+
+func platformDocumentsDir(home string) string {
+	if path, err := windows.KnownFolderPath(windows.FOLDERID_Documents, windows.KF_FLAG_DEFAULT); err == nil {
+		return path
+	}
+	return filepath.Join(home, "Documents")
+}
+
+func platformDesktopDir(home string) string {
+	if path, err := windows.KnownFolderPath(windows.FOLDERID_Desktop, windows.KF_FLAG_DEFAULT); err == nil {
+		return path
+	}
+	return filepath.Join(home, "Desktop")
+}
+
+// platformICloudDir has no Windows equivalent.
+func platformICloudDir(home string) string {
+	return ""
+}
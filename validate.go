@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// outputValidationKind classifies why validateOutput rejected a path, so
+// callers can react differently to each case instead of pattern-matching an
+// error string.
+type outputValidationKind int
+
+const (
+	outputMissingParent outputValidationKind = iota
+	outputAlreadyExists
+)
+
+// outputValidationError is returned by validateOutput; ExistingSize/
+// ExistingMod are only populated when Kind is outputAlreadyExists.
+type outputValidationError struct {
+	Kind         outputValidationKind
+	Path         string
+	ExistingSize int64
+	ExistingMod  time.Time
+}
+
+func (e *outputValidationError) Error() string {
+	switch e.Kind {
+	case outputMissingParent:
+		return fmt.Sprintf("parent directory of %s does not exist", e.Path)
+	case outputAlreadyExists:
+		return fmt.Sprintf("%s already exists", e.Path)
+	}
+	return "invalid output path"
+}
+
+// resolveOutputPath makes path absolute relative to baseDir (m.selectedDir)
+// when it isn't already, so a bare filename typed in selectingOutput lands
+// next to the scanned directory rather than wherever the process's CWD
+// happens to be.
+func resolveOutputPath(path, baseDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+// validateOutput checks m.outputPath (already resolved via
+// resolveOutputPath) for the two conditions that matter before processing
+// starts: a parent directory that doesn't exist yet, and a file that's
+// already there. It's a plain function of model rather than a method so a
+// future non-interactive CLI entry point can call the same checks the TUI
+// does without needing a bubbletea model to drive it.
+func validateOutput(m model) error {
+	path := m.outputPath
+
+	if _, err := os.Stat(filepath.Dir(path)); os.IsNotExist(err) {
+		return &outputValidationError{Kind: outputMissingParent, Path: path}
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		return &outputValidationError{
+			Kind:         outputAlreadyExists,
+			Path:         path,
+			ExistingSize: info.Size(),
+			ExistingMod:  info.ModTime(),
+		}
+	}
+
+	return nil
+}
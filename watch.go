@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"camila-app/internal/extractor"
+)
+
+// watchDebounce coalesces bursts of filesystem events (e.g. an editor doing
+// a write-then-rename-then-write on save) into a single rescan.
+const watchDebounce = 500 * time.Millisecond
+
+// watchEventMsg is sent over m.watchSub once per coalesced debounce window.
+type watchEventMsg struct {
+	lines []string // human-readable "CREATE /foo" style lines, oldest first
+	total int       // running count of changes applied so far
+}
+
+// watchStoppedMsg reports that the watcher goroutine has exited, either
+// because ctx was cancelled (the normal stop path) or because fsnotify
+// itself failed to start.
+type watchStoppedMsg struct {
+	err error
+}
+
+// startWatching transitions into the watching state and kicks off
+// runWatchMode, resetting the event tail the same way processing resets
+// progress state on entry.
+func (m model) startWatching() (tea.Model, tea.Cmd) {
+	m.state = watching
+	m.watchEvents = nil
+	m.watchCount = 0
+	m.watchSub = make(chan tea.Msg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.watchCancel = cancel
+
+	return m, tea.Batch(m.runWatchMode(ctx), waitForWatchEvent(m.watchSub))
+}
+
+func waitForWatchEvent(sub chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-sub
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// runWatchMode recursively watches m.selectedDir with fsnotify, debounces
+// bursts of events on a timer, and for each coalesced batch re-extracts
+// metadata for just the changed paths and patches them into an in-memory
+// path->FileRecord index before rewriting m.outputPath. It runs until ctx is
+// cancelled, at which point it flushes any pending batch and exits.
+func (m model) runWatchMode(ctx context.Context) tea.Cmd {
+	sub := m.watchSub
+	root := m.selectedDir
+	outputPath := m.outputPath
+	exportFormat := m.exportFormat
+	opts := extractor.Options{
+		Debug:           m.debugMode,
+		IncludeExt:      m.fileFilter.IncludeExts,
+		ExcludePatterns: m.fileFilter.ExcludePatterns,
+		ExcludeDirs:     m.fileFilter.ExcludeDirs,
+		IncludeMatches:  m.fileFilter.IncludeMatches,
+		ScanArchives:    m.scanArchives,
+	}
+
+	return func() tea.Msg {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return watchStoppedMsg{err: err}
+		}
+		defer watcher.Close()
+
+		if err := addWatchRecursive(watcher, root, opts.ExcludeDirs); err != nil {
+			return watchStoppedMsg{err: err}
+		}
+
+		index := map[string]extractor.FileRecord{}
+		if existing, _, err := extractor.Walk(ctx, root, opts, nil); err == nil {
+			for _, rec := range existing {
+				index[rec.Path] = rec
+			}
+		}
+
+		pending := map[string]string{} // path -> most recent event kind this window
+		var pendingOrder []string      // preserves the order paths were first touched
+		timerC := make(chan time.Time, 1)
+		var timer *time.Timer
+		total := 0
+
+		flush := func() {
+			if len(pending) == 0 {
+				return
+			}
+			lines := make([]string, 0, len(pendingOrder))
+			for _, path := range pendingOrder {
+				kind := pending[path]
+				if kind == "REMOVE" {
+					delete(index, path)
+				} else if recs := extractor.Rescan([]string{path}, opts); len(recs) > 0 {
+					index[path] = recs[0]
+				} else {
+					delete(index, path)
+				}
+				lines = append(lines, fmt.Sprintf("%s %s", kind, path))
+				total++
+			}
+			pending = map[string]string{}
+			pendingOrder = nil
+
+			records := make([]extractor.FileRecord, 0, len(index))
+			for _, rec := range index {
+				records = append(records, rec)
+			}
+			writeOutputs(records, outputPath, exportFormat)
+
+			sub <- watchEventMsg{lines: lines, total: total}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				flush()
+				close(sub)
+				return watchStoppedMsg{}
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					flush()
+					close(sub)
+					return watchStoppedMsg{}
+				}
+				kind := watchEventKind(event)
+				if kind == "" {
+					continue
+				}
+				if kind == "CREATE" {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						_ = addWatchRecursive(watcher, event.Name, opts.ExcludeDirs)
+						// A directory itself is never a row in the index (extractor.Walk's
+						// collectPaths skips d.IsDir() too) -- only queue its eventual
+						// file contents, which will arrive as their own CREATE events.
+						continue
+					}
+				}
+				if _, seen := pending[event.Name]; !seen {
+					pendingOrder = append(pendingOrder, event.Name)
+				}
+				pending[event.Name] = kind
+
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, func() {
+					select {
+					case timerC <- time.Now():
+					default:
+					}
+				})
+
+			case <-timerC:
+				flush()
+
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					flush()
+					close(sub)
+					return watchStoppedMsg{}
+				}
+				_ = watchErr // A single watcher error shouldn't stop the whole session.
+			}
+		}
+	}
+}
+
+// watchEventKind maps an fsnotify.Event to the CREATE/WRITE/REMOVE vocabulary
+// shown in the watching state's event tail. Rename is treated as a removal
+// of the old path (fsnotify reports the new path as its own Create).
+// Chmod-only events are ignored since they don't affect extracted metadata.
+func watchEventKind(event fsnotify.Event) string {
+	switch {
+	case event.Op&fsnotify.Create != 0:
+		return "CREATE"
+	case event.Op&fsnotify.Write != 0:
+		return "WRITE"
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		return "REMOVE"
+	}
+	return ""
+}
+
+// addWatchRecursive adds root and every subdirectory under it (skipping
+// names in excludeDirs) to watcher, so newly discovered directories from a
+// CREATE event can be folded in the same way at runtime.
+func addWatchRecursive(watcher *fsnotify.Watcher, root string, excludeDirs []string) error {
+	return filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries rather than aborting the whole watch.
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && watchDirExcluded(d.Name(), excludeDirs) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(p)
+	})
+}
+
+// watchDirExcluded reports whether name matches one of excludeDirs exactly,
+// mirroring internal/extractor's own (unexported) isExcludedDir check.
+func watchDirExcluded(name string, excludeDirs []string) bool {
+	for _, dir := range excludeDirs {
+		if name == dir {
+			return true
+		}
+	}
+	return false
+}
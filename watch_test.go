@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatchEventKind(t *testing.T) {
+	tests := []struct {
+		name string
+		op   fsnotify.Op
+		want string
+	}{
+		{"create", fsnotify.Create, "CREATE"},
+		{"write", fsnotify.Write, "WRITE"},
+		{"remove", fsnotify.Remove, "REMOVE"},
+		{"rename_treated_as_remove", fsnotify.Rename, "REMOVE"},
+		{"chmod_ignored", fsnotify.Chmod, ""},
+		{"create_takes_priority_over_write", fsnotify.Create | fsnotify.Write, "CREATE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := fsnotify.Event{Name: "/tmp/whatever", Op: tt.op}
+			if got := watchEventKind(event); got != tt.want {
+				t.Errorf("watchEventKind(%v) = %q, want %q", tt.op, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchDirExcluded(t *testing.T) {
+	excludeDirs := []string{"node_modules", ".git"}
+
+	tests := []struct {
+		name string
+		dir  string
+		want bool
+	}{
+		{"excluded_exact", "node_modules", true},
+		{"excluded_other", ".git", true},
+		{"not_excluded", "src", false},
+		{"no_substring_match", "my_node_modules", false},
+		{"empty_exclude_list", "node_modules", false},
+	}
+
+	for _, tt := range tests {
+		dirs := excludeDirs
+		if tt.name == "empty_exclude_list" {
+			dirs = nil
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if got := watchDirExcluded(tt.dir, dirs); got != tt.want {
+				t.Errorf("watchDirExcluded(%q, %v) = %v, want %v", tt.dir, dirs, got, tt.want)
+			}
+		})
+	}
+}